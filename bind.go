@@ -0,0 +1,409 @@
+package nexora
+
+import (
+	"encoding"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Decoder decodes the data read from r into v.
+//
+// Decoders are registered per media type with Nexora.RegisterDecoder and are
+// used by the Bind family of Context methods to decode request bodies.
+//
+// Example (msgpack):
+//
+//	n.RegisterDecoder("application/msgpack", func(r io.Reader, v any) error {
+//	    return msgpack.NewDecoder(r).Decode(v)
+//	})
+type Decoder func(r io.Reader, v any) error
+
+// defaultDecoders holds the decoders available to every Nexora instance.
+// Decoders registered with RegisterDecoder take precedence over these.
+var defaultDecoders = map[string]Decoder{
+	MIMEApplicationJSON: decodeJSON,
+	MIMEApplicationXML:  decodeXML,
+	MIMETextXML:         decodeXML,
+}
+
+func decodeJSON(r io.Reader, v any) error {
+	return json.NewDecoder(r).Decode(v)
+}
+
+func decodeXML(r io.Reader, v any) error {
+	return xml.NewDecoder(r).Decode(v)
+}
+
+// RegisterDecoder registers a body decoder for the given media type.
+// Parameters such as charset are ignored, so "application/json; charset=utf-8"
+// and "application/json" refer to the same decoder.
+// Registering a decoder for a built-in media type replaces the default one.
+func (n *Nexora) RegisterDecoder(mediaType string, decoder Decoder) {
+	if decoder == nil {
+		panic("nexora: decoder must not be nil")
+	}
+	if n.decoders == nil {
+		n.decoders = make(map[string]Decoder)
+	}
+	n.decoders[parseMediaType(mediaType)] = decoder
+}
+
+// decoder returns the decoder registered for the given media type.
+// Structured syntax suffixes such as "+json" and "+xml" fall back to the
+// decoder of the base format. Nil is returned if no decoder is found.
+func (n *Nexora) decoder(mediaType string) Decoder {
+	if n != nil {
+		if d, ok := n.decoders[mediaType]; ok {
+			return d
+		}
+	}
+	if d, ok := defaultDecoders[mediaType]; ok {
+		return d
+	}
+
+	switch {
+	case strings.HasSuffix(mediaType, "+json"):
+		return n.decoder(MIMEApplicationJSON)
+	case strings.HasSuffix(mediaType, "+xml"):
+		return n.decoder(MIMEApplicationXML)
+	}
+	return nil
+}
+
+// parseMediaType returns the lower-cased media type of a Content-Type value
+// without any parameters.
+func parseMediaType(contentType string) string {
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		return mediaType
+	}
+	if i := strings.IndexByte(contentType, ';'); i >= 0 {
+		contentType = contentType[:i]
+	}
+	return strings.ToLower(strings.TrimSpace(contentType))
+}
+
+// Bind binds the request data into dst, which must be a pointer to a struct.
+//
+// Route parameters are bound first using the `param` tag. For GET, HEAD and
+// DELETE requests the query string is bound using the `query` tag. If the
+// request has a body it is decoded based on its Content-Type: form
+// submissions are bound using the `form` tag and every other media type is
+// handed to the registered Decoder.
+//
+// On failure an *HTTPError is returned: 400 for malformed data, 415 for an
+// unsupported Content-Type and 422 for values that cannot be converted to the
+// type of the destination field. Once bound, dst is validated with
+// Context.Validate and a ValidationErrors value is returned if it is invalid.
+//
+// The other Bind methods behave the same way for a single source of data,
+// but don't validate dst, so that it can be bound from several sources.
+// Call Context.Validate once all of them are bound.
+//
+// Example:
+//
+//	type CreateUser struct {
+//	    Org  string `param:"org"`
+//	    Name string `json:"name" form:"name"`
+//	}
+//
+//	var in CreateUser
+//	if err := c.Bind(&in); err != nil {
+//	    return err
+//	}
+func (c *Context) Bind(dst any) error {
+	if err := c.bindParams(dst); err != nil {
+		return err
+	}
+
+	switch c.request.Method {
+	case MethodGet, MethodHead, MethodDelete:
+		if err := c.bindQuery(dst); err != nil {
+			return err
+		}
+	}
+
+	if c.request.ContentLength != 0 && c.request.Body != nil && c.request.Body != http.NoBody {
+		if err := c.bindBody(dst); err != nil {
+			return err
+		}
+	}
+
+	return c.Validate(dst)
+}
+
+// BindBody decodes the request body into dst based on the request Content-Type.
+// Form submissions are bound using the `form` tag. An *HTTPError with status
+// 415 is returned if no decoder is registered for the Content-Type.
+func (c *Context) BindBody(dst any) error {
+	return c.bindBody(dst)
+}
+
+// BindJSON decodes the request body as JSON into dst, regardless of the
+// request Content-Type.
+func (c *Context) BindJSON(dst any) error {
+	return c.decodeBody(c.nexora.decoder(MIMEApplicationJSON), dst)
+}
+
+// BindXML decodes the request body as XML into dst, regardless of the
+// request Content-Type.
+func (c *Context) BindXML(dst any) error {
+	return c.decodeBody(c.nexora.decoder(MIMEApplicationXML), dst)
+}
+
+// BindForm binds URL-encoded and multipart form values into dst using the
+// `form` tag. Values from the query string are included as well, with body
+// values taking precedence.
+func (c *Context) BindForm(dst any) error {
+	return c.bindForm(dst)
+}
+
+// BindQuery binds the URL query parameters into dst using the `query` tag.
+func (c *Context) BindQuery(dst any) error {
+	return c.bindQuery(dst)
+}
+
+// BindParams binds the route parameters into dst using the `param` tag.
+func (c *Context) BindParams(dst any) error {
+	return c.bindParams(dst)
+}
+
+// BindHeaders binds the request headers into dst using the `header` tag.
+// Header names are matched case-insensitively.
+func (c *Context) BindHeaders(dst any) error {
+	return c.bindHeaders(dst)
+}
+
+func (c *Context) bindBody(dst any) error {
+	mediaType := parseMediaType(c.GetHeader(HeaderContentType))
+
+	switch mediaType {
+	case MIMEApplicationForm, MIMEMultipartForm:
+		return c.bindForm(dst)
+	case "":
+		return ErrUnsupportedMediaType
+	}
+
+	decoder := c.nexora.decoder(mediaType)
+	if decoder == nil {
+		return ErrUnsupportedMediaType
+	}
+	return c.decodeBody(decoder, dst)
+}
+
+func (c *Context) bindForm(dst any) error {
+	if err := c.parseForm(); err != nil {
+		return err
+	}
+
+	form := c.request.Form
+	return bindData(dst, "form", func(name string) []string {
+		return form[name]
+	})
+}
+
+func (c *Context) bindQuery(dst any) error {
+	query := c.Queries()
+	return bindData(dst, "query", func(name string) []string {
+		return query[name]
+	})
+}
+
+func (c *Context) bindParams(dst any) error {
+	return bindData(dst, "param", func(name string) []string {
+		if value, ok := c.ParamExists(name); ok {
+			return []string{value}
+		}
+		return nil
+	})
+}
+
+func (c *Context) bindHeaders(dst any) error {
+	header := c.request.Header
+	return bindData(dst, "header", func(name string) []string {
+		return header.Values(name)
+	})
+}
+
+// decodeBody decodes the request body into dst using decoder and maps
+// decoding failures to an *HTTPError.
+func (c *Context) decodeBody(decoder Decoder, dst any) error {
+	if _, err := c.readBody(); err != nil {
+		return err
+	}
+
+	err := decoder(c.BodyReader(), dst)
+	if err == nil {
+		return nil
+	}
+
+	var (
+		typeErr   *json.UnmarshalTypeError
+		syntaxErr *json.SyntaxError
+		invalid   *json.InvalidUnmarshalError
+	)
+
+	switch {
+	case errors.As(err, &invalid):
+		return err
+	case errors.As(err, &typeErr):
+		return NewHTTPError(StatusUnprocessableEntity,
+			fmt.Sprintf("invalid value for field %q: expected %s", typeErr.Field, typeErr.Type))
+	case errors.As(err, &syntaxErr):
+		return NewHTTPError(StatusBadRequest,
+			fmt.Sprintf("invalid request body at offset %d: %v", syntaxErr.Offset, syntaxErr))
+	case errors.Is(err, io.EOF):
+		return NewHTTPError(StatusBadRequest, "request body is empty")
+	}
+
+	return NewHTTPError(StatusBadRequest, "invalid request body: "+err.Error())
+}
+
+var errUnsupportedFieldType = errors.New("nexora: unsupported field type")
+
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// bindData fills the tagged fields of the struct pointed to by dst with the
+// values returned by lookup. Fields without the tag are skipped, except
+// embedded and nested structs which are walked recursively.
+func bindData(dst any, tag string, lookup func(name string) []string) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("nexora: bind destination must be a non-nil pointer, got %T", dst)
+	}
+
+	rv = rv.Elem()
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("nexora: bind destination must point to a struct, got %T", dst)
+	}
+
+	return bindStruct(rv, tag, lookup)
+}
+
+func bindStruct(rv reflect.Value, tag string, lookup func(name string) []string) error {
+	rt := rv.Type()
+
+	for i := 0; i < rt.NumField(); i++ {
+		sf := rt.Field(i)
+		fv := rv.Field(i)
+
+		if !sf.IsExported() && !sf.Anonymous {
+			continue
+		}
+
+		name, _, _ := strings.Cut(sf.Tag.Get(tag), ",")
+		if name == "-" {
+			continue
+		}
+
+		if name == "" {
+			if fv.Kind() == reflect.Struct && fv.CanSet() && !reflect.PointerTo(fv.Type()).Implements(textUnmarshalerType) {
+				if err := bindStruct(fv, tag, lookup); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		values := lookup(name)
+		if len(values) == 0 || !fv.CanSet() {
+			continue
+		}
+
+		if err := setField(fv, values); err != nil {
+			if errors.Is(err, errUnsupportedFieldType) {
+				return err
+			}
+			return NewHTTPError(StatusUnprocessableEntity,
+				fmt.Sprintf("invalid value %q for field %q", values[0], name))
+		}
+	}
+
+	return nil
+}
+
+// setField converts values to the type of fv and stores the result.
+// Slices receive every value, all other types only the first one.
+func setField(fv reflect.Value, values []string) error {
+	if fv.Kind() == reflect.Pointer {
+		if fv.IsNil() {
+			fv.Set(reflect.New(fv.Type().Elem()))
+		}
+		return setField(fv.Elem(), values)
+	}
+
+	if fv.CanAddr() && fv.Addr().Type().Implements(textUnmarshalerType) {
+		return fv.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(values[0]))
+	}
+
+	if fv.Kind() == reflect.Slice && fv.Type().Elem().Kind() != reflect.Uint8 {
+		slice := reflect.MakeSlice(fv.Type(), len(values), len(values))
+		for i, value := range values {
+			if err := setField(slice.Index(i), []string{value}); err != nil {
+				return err
+			}
+		}
+		fv.Set(slice)
+		return nil
+	}
+
+	return setValue(fv, values[0])
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// setValue converts a single string value to the kind of fv.
+func setValue(fv reflect.Value, value string) error {
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(value)
+	case reflect.Slice:
+		fv.SetBytes([]byte(value))
+	case reflect.Bool:
+		if value == "" {
+			value = "false"
+		}
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		fv.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if fv.Type() == durationType {
+			d, err := time.ParseDuration(value)
+			if err != nil {
+				return err
+			}
+			fv.SetInt(int64(d))
+			return nil
+		}
+		i, err := strconv.ParseInt(value, 10, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(value, 10, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetFloat(f)
+	default:
+		return fmt.Errorf("%w %s", errUnsupportedFieldType, fv.Type())
+	}
+	return nil
+}
@@ -0,0 +1,191 @@
+package nexora
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type bindUser struct {
+	Org     string        `param:"org"`
+	Name    string        `json:"name" xml:"name" form:"name" query:"name"`
+	Age     int           `json:"age" xml:"age" form:"age" query:"age"`
+	Tags    []string      `json:"tags" form:"tag" query:"tag"`
+	Timeout time.Duration `query:"timeout"`
+	Token   string        `header:"X-Token"`
+	Active  *bool         `query:"active"`
+}
+
+func TestContext_BindJSON(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/orgs/acme/users", strings.NewReader(`{"name":"alice","age":30,"tags":["a","b"]}`))
+	req.Header.Set(HeaderContentType, MIMEApplicationJSONCharsetUTF8)
+	rec := httptest.NewRecorder()
+
+	ctx := newContext(nil)
+	ctx.init(req, rec)
+	ctx.params = pathParams{{key: "org", value: "acme"}}
+
+	var u bindUser
+	if err := ctx.Bind(&u); err != nil {
+		t.Fatalf("Bind() error = %v", err)
+	}
+	if u.Org != "acme" || u.Name != "alice" || u.Age != 30 || len(u.Tags) != 2 {
+		t.Errorf("Bind() = %+v", u)
+	}
+}
+
+func TestContext_BindXML(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`<user><name>bob</name><age>41</age></user>`))
+	req.Header.Set(HeaderContentType, MIMETextXML)
+	rec := httptest.NewRecorder()
+
+	ctx := newContext(nil)
+	ctx.init(req, rec)
+
+	var u bindUser
+	if err := ctx.Bind(&u); err != nil {
+		t.Fatalf("Bind() error = %v", err)
+	}
+	if u.Name != "bob" || u.Age != 41 {
+		t.Errorf("Bind() = %+v", u)
+	}
+}
+
+func TestContext_BindForm(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("name=carol&age=22&tag=x&tag=y"))
+	req.Header.Set(HeaderContentType, MIMEApplicationForm)
+	rec := httptest.NewRecorder()
+
+	ctx := newContext(nil)
+	ctx.init(req, rec)
+
+	var u bindUser
+	if err := ctx.Bind(&u); err != nil {
+		t.Fatalf("Bind() error = %v", err)
+	}
+	if u.Name != "carol" || u.Age != 22 || strings.Join(u.Tags, ",") != "x,y" {
+		t.Errorf("Bind() = %+v", u)
+	}
+}
+
+func TestContext_BindQueryAndHeaders(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/?name=dave&age=7&timeout=2s&active=true", nil)
+	req.Header.Set("x-token", "secret")
+	rec := httptest.NewRecorder()
+
+	ctx := newContext(nil)
+	ctx.init(req, rec)
+
+	var u bindUser
+	if err := ctx.Bind(&u); err != nil {
+		t.Fatalf("Bind() error = %v", err)
+	}
+	if err := ctx.BindHeaders(&u); err != nil {
+		t.Fatalf("BindHeaders() error = %v", err)
+	}
+	if u.Name != "dave" || u.Age != 7 || u.Timeout != 2*time.Second || u.Token != "secret" {
+		t.Errorf("Bind() = %+v", u)
+	}
+	if u.Active == nil || !*u.Active {
+		t.Errorf("Bind() Active = %v, want true", u.Active)
+	}
+}
+
+func TestContext_BindErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		method      string
+		target      string
+		contentType string
+		body        string
+		status      int
+	}{
+		{"malformed json", http.MethodPost, "/", MIMEApplicationJSON, `{"name":`, StatusBadRequest},
+		{"json type mismatch", http.MethodPost, "/", MIMEApplicationJSON, `{"age":"old"}`, StatusUnprocessableEntity},
+		{"unsupported media type", http.MethodPost, "/", "text/csv", "a,b", StatusUnsupportedMediaType},
+		{"missing content type", http.MethodPost, "/", "", "{}", StatusUnsupportedMediaType},
+		{"query conversion", http.MethodGet, "/?age=abc", "", "", StatusUnprocessableEntity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body io.Reader
+			if tt.body != "" {
+				body = strings.NewReader(tt.body)
+			}
+			req := httptest.NewRequest(tt.method, tt.target, body)
+			if tt.contentType != "" {
+				req.Header.Set(HeaderContentType, tt.contentType)
+			}
+
+			ctx := newContext(nil)
+			ctx.init(req, httptest.NewRecorder())
+
+			var u bindUser
+			err := ctx.Bind(&u)
+
+			var httpErr *HTTPError
+			if !errors.As(err, &httpErr) {
+				t.Fatalf("Bind() error = %v, want *HTTPError", err)
+			}
+			if httpErr.StatusCode != tt.status {
+				t.Errorf("Bind() status = %d, want %d", httpErr.StatusCode, tt.status)
+			}
+		})
+	}
+}
+
+func TestContext_BindInvalidTarget(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/?name=x", nil)
+	ctx := newContext(nil)
+	ctx.init(req, httptest.NewRecorder())
+
+	var u bindUser
+	if err := ctx.BindQuery(u); err == nil {
+		t.Error("BindQuery() with non-pointer should fail")
+	}
+}
+
+func TestNexora_RegisterDecoder(t *testing.T) {
+	n := New()
+	n.RegisterDecoder("text/csv; charset=utf-8", func(r io.Reader, v any) error {
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return err
+		}
+		fields := strings.Split(strings.TrimSpace(string(data)), ",")
+		u := v.(*bindUser)
+		u.Name = fields[0]
+		return nil
+	})
+
+	n.Post("/users", func(c *Context) error {
+		var u bindUser
+		if err := c.Bind(&u); err != nil {
+			return err
+		}
+		return c.SendString(u.Name)
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader("erin,42"))
+	req.Header.Set(HeaderContentType, "text/csv")
+	rec := httptest.NewRecorder()
+	n.ServeHTTP(rec, req)
+
+	if rec.Body.String() != "erin" {
+		t.Errorf("body = %q, want %q", rec.Body.String(), "erin")
+	}
+}
+
+func TestNexora_DecoderStructuredSuffix(t *testing.T) {
+	if New().decoder("application/vnd.acme.v2+json") == nil {
+		t.Error("expected +json media type to fall back to the JSON decoder")
+	}
+	if New().decoder("application/unknown") != nil {
+		t.Error("expected no decoder for unknown media type")
+	}
+}
@@ -0,0 +1,304 @@
+package nexora
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// RouteConflict describes a route which is never matched, because a route
+// registered before it matches the same requests, or which is ambiguous with
+// a route registered before it. Conflicts are reported by Nexora.Validate,
+// ambiguous routes by Nexora.Warnings.
+type RouteConflict struct {
+	Method string
+	Host   string
+	Path   string // Path of the route, or of the expansion of its optional parameters which conflicts
+	Source string // File and line where the route was registered
+
+	// Ambiguous is true if both routes have parameters of the same priority
+	// in the segment where their paths differ, which may match the same
+	// values, e.g. /items/{id:int} and /items/{n:uint}. Requests matching
+	// both are routed to the route registered before, the other one is only
+	// matched by the requests the route registered before doesn't match.
+	Ambiguous bool
+
+	// Path of the route registered before and the file and line where it
+	// was registered. They are empty if the conflict was found by the
+	// routing tree, in which case the error describes it.
+	ExistingPath   string
+	ExistingSource string
+
+	err error
+}
+
+// Error implements the error interface.
+func (c *RouteConflict) Error() string {
+	route := c.Method + " " + c.Host + c.Path
+	if c.ExistingPath == "" {
+		return fmt.Sprintf("nexora: route %s registered at %s conflicts with another route: %s",
+			route, c.Source, strings.TrimPrefix(c.err.Error(), "nexora: "))
+	}
+	relation := "is shadowed by"
+	if c.Ambiguous {
+		relation = "is ambiguous with"
+	}
+	return fmt.Sprintf("nexora: route %s registered at %s %s %s %s%s registered at %s",
+		route, c.Source, relation, c.Method, c.Host, c.ExistingPath, c.ExistingSource)
+}
+
+// Validate reports the routes which are never matched for some of their
+// paths, because a route registered before them matches the same requests.
+// It returns nil if there are none, or else the *RouteConflict of each of
+// them joined with errors.Join, in the order the routes were registered.
+// Ambiguous routes are not errors, they are reported by Warnings.
+//
+// Run and its variants call Validate and don't start the server if it fails.
+// Serving requests with ServeHTTP directly, e.g. with httptest or an
+// http.Server created by the application, doesn't check them, so such
+// applications should call Validate themselves.
+//
+// When several routes match a request, the route is selected by priority,
+// segment by segment, regardless of the order they were registered in:
+//
+//  1. Static segments, e.g. /users/new
+//  2. Parameters with a constraint or a regular expression, e.g. /users/{id:int}
+//  3. Plain parameters, e.g. /users/{name}
+//  4. Wildcards, e.g. /users/{path:*}
+//
+// Parameters of the same kind are tried in the order they were registered.
+// If a route doesn't match the rest of the path, the next one is tried.
+//
+// A route conflicts with a route registered before it if their paths only
+// differ by the names of their parameters or a trailing slash, e.g.
+// /users/{id} and /users/{name}. Registering a route which would never be
+// matched panics with its *RouteConflict, as does registering a route with
+// the same path as a route without matcher (see Route.Match). If only some
+// of the paths of a route with optional parameters conflict, e.g. /tags/{tag?}
+// registered after /tags, the other ones are added to the routing tree and
+// the conflicts are reported by Validate.
+//
+// Example:
+//
+//	if err := app.Validate(); err != nil {
+//		log.Fatal(err)
+//	}
+func (n *Nexora) Validate() error {
+	var errs []error
+	for _, r := range n.routes {
+		for _, c := range r.conflicts {
+			if !c.Ambiguous {
+				errs = append(errs, c)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Warnings returns the routes which are ambiguous with a route registered
+// before them, in the order they were registered. They are not errors: Run
+// and its variants log them and start the server.
+//
+// A route is ambiguous with a route registered before it if, in the first
+// segment where their paths differ, both have a parameter with a constraint
+// or a regular expression, which may match the same values, and the rest of
+// the paths may match the same segments, e.g. /items/{id:int} and
+// /items/{n:uint}, or /tags/{t:[a-z]+} and /tags/{t:[a-z0-9]+}. Both paths
+// are added to the routing tree, and the order the routes were registered in
+// decides which one handles a request matching both.
+func (n *Nexora) Warnings() []*RouteConflict {
+	var warnings []*RouteConflict
+	for _, r := range n.routes {
+		for _, c := range r.conflicts {
+			if c.Ambiguous {
+				warnings = append(warnings, c)
+			}
+		}
+	}
+	return warnings
+}
+
+// routePath is a path under which a route was added to a routing tree.
+type routePath struct {
+	route *Route
+	path  string
+	shape []string // Segments of the shape of the path, see pathShape
+}
+
+// addPath adds a path of the route to the tree, unless a route registered
+// before has a path with the same shape, in which case the conflict is
+// recorded on the route. The paths of routes registered before which are
+// ambiguous with it are recorded too. It reports whether the path was added,
+// handle panics if none of the paths of the route was.
+func (n *Nexora) addPath(tree *tree, r *Route, path string, handlers []Handler) (added bool) {
+	key := r.host() + " " + r.method
+	shape := pathShape(path)
+
+	var ambiguous []*RouteConflict
+	for _, existing := range n.routePaths[key] {
+		switch {
+		case slices.Equal(existing.shape, shape):
+			r.conflicts = append(r.conflicts, newRouteConflict(r, path, existing.route, existing.path))
+			return false
+		case existing.route != r && ambiguousShapes(existing.shape, shape):
+			conflict := newRouteConflict(r, path, existing.route, existing.path)
+			conflict.Ambiguous = true
+			ambiguous = append(ambiguous, conflict)
+		}
+	}
+
+	defer func() {
+		if rcv := recover(); rcv != nil {
+			err, ok := rcv.(radixError)
+			if !ok || err.msg != errSetHandler && err.msg != errSetWildcardHandler && err.msg != errWildcardConflict {
+				panic(rcv)
+			}
+			r.conflicts = append(r.conflicts, &RouteConflict{
+				Method: r.method,
+				Host:   r.host(),
+				Path:   path,
+				Source: r.source,
+				err:    err,
+			})
+		}
+	}()
+
+	tree.Add(path, handlers)
+
+	r.conflicts = append(r.conflicts, ambiguous...)
+	if n.routePaths == nil {
+		n.routePaths = make(map[string][]routePath)
+	}
+	n.routePaths[key] = append(n.routePaths[key], routePath{r, path, shape})
+	return true
+}
+
+func newRouteConflict(r *Route, path string, existing *Route, existingPath string) *RouteConflict {
+	return &RouteConflict{
+		Method:         r.method,
+		Host:           r.host(),
+		Path:           path,
+		Source:         r.source,
+		ExistingPath:   existingPath,
+		ExistingSource: existing.source,
+	}
+}
+
+// pathShape returns the segments of the path without the names of its
+// parameters and without trailing slash, so that paths matching the same
+// requests have the same shape. Parameters are written as {} if they are
+// plain, {:constraint} if they have a constraint and {*} for wildcards.
+func pathShape(path string) []string {
+	var (
+		shape   []string
+		segment strings.Builder
+	)
+	for i := 1; i < len(path); i++ {
+		switch path[i] {
+		case '/':
+			shape = append(shape, segment.String())
+			segment.Reset()
+			continue
+		case '{':
+		default:
+			segment.WriteByte(path[i])
+			continue
+		}
+
+		end := min(paramEnd(path, i), len(path)-1)
+		param := routeParams(path[i : end+1])[0]
+		switch {
+		case param.Wildcard:
+			segment.WriteString("{*}")
+		case param.Constraint != "":
+			segment.WriteString("{:" + param.Constraint + "}")
+		default:
+			segment.WriteString("{}")
+		}
+		i = end
+	}
+
+	if segment.Len() > 0 {
+		shape = append(shape, segment.String())
+	}
+	return shape
+}
+
+// ambiguousShapes reports whether paths with the given shapes may match the
+// same requests, with the route selected by the order they were registered
+// in: in the first segment where they differ, both have a parameter with a
+// constraint or a regular expression at the same position, and the rest of
+// the paths may match the same segments.
+func ambiguousShapes(a, b []string) bool {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return ambiguousSegments(a[i], b[i]) && shapesMayOverlap(a[i+1:], b[i+1:])
+		}
+	}
+	return false
+}
+
+// ambiguousSegments reports whether two different segments of shapes have
+// parameters with a constraint or a regular expression after their common
+// static prefix, and static suffixes which don't exclude each other.
+func ambiguousSegments(a, b string) bool {
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] && a[i] != '{' {
+		i++
+	}
+	a, b = a[i:], b[i:]
+	if !strings.HasPrefix(a, "{") || !strings.HasPrefix(b, "{") {
+		// A static part is tried before a parameter, and different static
+		// parts don't match the same values
+		return false
+	}
+
+	for _, s := range []string{a, b} {
+		if s == "{}" || strings.HasPrefix(s, "{*}") {
+			// Plain parameters are tried after those with a constraint,
+			// and wildcards last
+			return false
+		}
+	}
+
+	suffixA, suffixB := a[strings.LastIndexByte(a, '}')+1:], b[strings.LastIndexByte(b, '}')+1:]
+	return strings.HasSuffix(suffixA, suffixB) || strings.HasSuffix(suffixB, suffixA)
+}
+
+// shapesMayOverlap reports whether paths with the given shapes may match the
+// same segments.
+func shapesMayOverlap(a, b []string) bool {
+	for i := 0; ; i++ {
+		switch {
+		case i < len(a) && strings.Contains(a[i], "{*}"), i < len(b) && strings.Contains(b[i], "{*}"):
+			return true
+		case i == len(a) || i == len(b):
+			return len(a) == len(b)
+		case a[i] != b[i] && !strings.Contains(a[i], "{") && !strings.Contains(b[i], "{"):
+			return false
+		}
+	}
+}
+
+// packagePath is the import path of the package.
+var packagePath = reflect.TypeFor[Nexora]().PkgPath()
+
+// callerSource returns the file and line of the first caller outside of the
+// package, or in its tests, which is where a route was registered.
+func callerSource() string {
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, packagePath+".") || strings.HasSuffix(frame.File, "_test.go") {
+			return frame.File + ":" + strconv.Itoa(frame.Line)
+		}
+		if !more {
+			return "unknown"
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package nexora
+
+import (
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNexora_RoutePriority(t *testing.T) {
+	routes := []struct {
+		path, body string
+	}{
+		{"/users/new", "static"},
+		{"/users/{id:int}", "int"},
+		{"/users/{name}", "plain"},
+		{"/users/{path:*}", "wildcard"},
+		{"/files/{name:[a-z]+}.txt", "regex"},
+		{"/files/{name}", "plain"},
+	}
+
+	tests := []struct {
+		target, body string
+	}{
+		{"/users/new", "static"},
+		{"/users/42", "int"},
+		{"/users/bob", "plain"},
+		{"/users/bob/posts", "wildcard"},
+		{"/files/notes.txt", "regex"},
+		{"/files/notes.md", "plain"},
+	}
+
+	// The priority doesn't depend on the registration order
+	for _, reverse := range []bool{false, true} {
+		app := New()
+		for i := range routes {
+			if reverse {
+				i = len(routes) - 1 - i
+			}
+			app.Get(routes[i].path, dummyHandler(routes[i].body))
+		}
+
+		for _, tt := range tests {
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, httptest.NewRequest("GET", tt.target, nil))
+			if rec.Body.String() != tt.body {
+				t.Errorf("reverse=%v: GET %s = %q, want %q", reverse, tt.target, rec.Body.String(), tt.body)
+			}
+		}
+
+		if err := app.Validate(); err != nil {
+			t.Errorf("reverse=%v: Validate() = %v", reverse, err)
+		}
+	}
+}
+
+func TestNexora_Validate(t *testing.T) {
+	app := New()
+	app.Get("/users/{id}", dummyHandler("id"))
+	app.Get("/posts/{id:int}", dummyHandler("post"))
+	app.Get("/tags", dummyHandler("tags"))
+	app.Get("/tags/{tag?}", dummyHandler("tag"))
+	app.Get("/report", dummyHandler("csv")).Headers("Accept", "text/csv")
+	app.Get("/report", dummyHandler("report"))
+	app.Host("{tenant}.example.com").Get("/users/{user}", dummyHandler("tenant"))
+
+	// Parameters of the same priority which may match the same values
+	app.Get("/a/{x:int}", dummyHandler("int"))
+	app.Get("/a/{y:uint}", dummyHandler("uint"))
+	app.Get("/b/{x:[a-z]+}", dummyHandler("alpha"))
+	app.Get("/b/{y:[a-z0-9]+}", dummyHandler("alnum"))
+	app.Get("/c/{x:int}/{rest:*}", dummyHandler("rest"))
+	app.Get("/c/{y:uint}/z", dummyHandler("z"))
+
+	// Paths which can't match the same requests, or whose priority differs
+	app.Get("/files/{name:[a-z]+}.txt", dummyHandler("txt"))
+	app.Get("/files/{name:[a-z]+}.md", dummyHandler("md"))
+	app.Get("/d/{x:int}/b", dummyHandler("b"))
+	app.Get("/d/{y:uint}/c", dummyHandler("c"))
+	app.Get("/e/{x:int}", dummyHandler("int"))
+	app.Get("/e/{y}", dummyHandler("plain"))
+	app.Get("/f/v{x:int}", dummyHandler("v"))
+	app.Get("/f/{y:uint}", dummyHandler("uint"))
+
+	// Routes which would never be matched panic
+	for _, path := range []string{"/users/{name}", "/posts/{slug:int}/"} {
+		func() {
+			defer func() {
+				var conflict *RouteConflict
+				if err, _ := recover().(error); !errors.As(err, &conflict) || conflict.Path != path || conflict.Ambiguous {
+					t.Errorf("Get(%s) panic = %v, want a *RouteConflict", path, err)
+				} else if msg := conflict.Error(); !strings.Contains(msg, "GET "+path+" registered at ") || !strings.Contains(msg, "is shadowed by GET ") {
+					t.Errorf("Error() = %q", msg)
+				}
+			}()
+			app.Get(path, dummyHandler("shadowed"))
+		}()
+	}
+
+	// The routes registered first are matched
+	for target, body := range map[string]string{"/users/bob": "id", "/tags": "tags", "/tags/go": "tag", "/a/5": "int", "/b/abc": "alpha", "/b/abc1": "alnum"} {
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, httptest.NewRequest("GET", target, nil))
+		if rec.Body.String() != body {
+			t.Errorf("GET %s = %q, want %q", target, rec.Body.String(), body)
+		}
+	}
+
+	err := app.Validate()
+	var conflict *RouteConflict
+	if !errors.As(err, &conflict) || len(err.(interface{ Unwrap() []error }).Unwrap()) != 1 {
+		t.Fatalf("Validate() = %v, want one conflict", err)
+	}
+	if conflict.Method != MethodGet || conflict.Path != "/tags" || conflict.ExistingPath != "/tags" || conflict.Ambiguous {
+		t.Errorf("conflict = %s %s with %s (ambiguous %v), want /tags with /tags", conflict.Method, conflict.Path, conflict.ExistingPath, conflict.Ambiguous)
+	}
+	if !strings.Contains(conflict.Source, "conflict_test.go:") || !strings.Contains(conflict.ExistingSource, "conflict_test.go:") || conflict.Source == conflict.ExistingSource {
+		t.Errorf("conflict sources = %s and %s", conflict.Source, conflict.ExistingSource)
+	}
+
+	want := []struct {
+		path, existing string
+	}{
+		{"/a/{y:uint}", "/a/{x:int}"},
+		{"/b/{y:[a-z0-9]+}", "/b/{x:[a-z]+}"},
+		{"/c/{y:uint}/z", "/c/{x:int}/{rest:*}"},
+	}
+	warnings := app.Warnings()
+	if len(warnings) != len(want) {
+		t.Fatalf("Warnings() = %v, want %d warnings", warnings, len(want))
+	}
+	for i, w := range want {
+		c := warnings[i]
+		if c.Method != MethodGet || c.Path != w.path || c.ExistingPath != w.existing || !c.Ambiguous {
+			t.Errorf("warning %d = %s %s with %s (ambiguous %v), want %s with %s", i, c.Method, c.Path, c.ExistingPath, c.Ambiguous, w.path, w.existing)
+		}
+	}
+	if msg := warnings[0].Error(); !strings.Contains(msg, "GET /a/{y:uint} registered at ") || !strings.Contains(msg, "is ambiguous with GET /a/{x:int}") {
+		t.Errorf("Error() = %q", msg)
+	}
+
+	if err := app.Run("127.0.0.1:0"); !errors.Is(err, conflict) {
+		t.Errorf("Run() = %v, want the conflict", err)
+	}
+}
+
+func TestNexora_RunAmbiguousRoutes(t *testing.T) {
+	app := New()
+	app.Get("/posts/{id:int}", dummyHandler("id"))
+	app.Get("/posts/{slug:slug}", dummyHandler("slug"))
+
+	if err := app.Validate(); err != nil {
+		t.Errorf("Validate() = %v, want nil", err)
+	}
+	if len(app.Warnings()) != 1 {
+		t.Errorf("Warnings() = %v, want one warning", app.Warnings())
+	}
+
+	// Run gets past the validation and calls the OnStart hooks
+	started := errors.New("started")
+	app.OnStart(func() error { return started })
+	if err := app.Run("127.0.0.1:0"); !errors.Is(err, started) {
+		t.Errorf("Run() = %v, want %v", err, started)
+	}
+}
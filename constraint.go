@@ -0,0 +1,96 @@
+package nexora
+
+import (
+	"regexp"
+)
+
+// Constraint is a parameter type registered with Nexora.RegisterConstraint.
+type Constraint struct {
+	// Pattern is the regular expression values must match. If it is empty,
+	// values match a whole path segment, or a label in host patterns.
+	Pattern string
+
+	// Parse validates a value matching Pattern and converts it. arg is the
+	// argument of the constraint, e.g. "2006-01-02" for {day:date(2006-01-02)},
+	// or empty if it has none. Values for which Parse returns an error don't
+	// match the route. The returned value is available through ParamAs with
+	// its own type. If Parse is nil, the constraint takes no argument.
+	Parse func(value, arg string) (any, error)
+}
+
+// RegisterConstraint registers a parameter type which can be used in the
+// paths and host patterns of the routes of n, e.g. {sku:sku}. It replaces
+// the built-in type with the same name, if any. Using a constraint which is
+// neither registered nor built-in is an error, so RegisterConstraint must be
+// called before the routes using it are registered.
+//
+// It panics if the name is not made of letters, digits and underscores, or if
+// the pattern is not a valid regular expression.
+//
+// Example:
+//
+//	app.RegisterConstraint("sku", nexora.Constraint{Pattern: `[A-Z]{3}-\d{4}`})
+//	app.RegisterConstraint("date", nexora.Constraint{
+//		Pattern: `[^/]+`,
+//		Parse: func(value, layout string) (any, error) {
+//			if layout == "" {
+//				layout = time.DateOnly
+//			}
+//			return time.Parse(layout, value)
+//		},
+//	})
+//
+//	app.Get("/products/{sku:sku}", getProduct)
+//	app.Get("/reports/{day:date(02-01-2006)}", func(c *nexora.Context) error {
+//		day, err := nexora.ParamAs[time.Time](c, "day")
+//		...
+//	})
+func (n *Nexora) RegisterConstraint(name string, c Constraint) {
+	if !isConstraintName(name) {
+		panicf("nexora: invalid constraint name %q", name)
+	}
+	if _, err := regexp.Compile(c.Pattern); err != nil {
+		panicf("nexora: invalid pattern of constraint %q: %v", name, err)
+	}
+
+	if n.constraints == nil {
+		n.constraints = make(map[string]Constraint)
+	}
+	n.constraints[name] = c
+}
+
+// resolveConstraint resolves a constraint as written in a path with the
+// constraints registered with RegisterConstraint, or else the built-in ones.
+// The pattern of the result is empty if the registered constraint has none.
+func (n *Nexora) resolveConstraint(spec string) (constraint, bool) {
+	name, arg := splitConstraint(spec)
+	c, ok := n.constraints[name]
+	if !ok {
+		return resolveConstraint(spec)
+	}
+
+	if c.Parse == nil {
+		if arg != "" {
+			panicf("nexora: constraint %q takes no argument", spec)
+		}
+		return constraint{pattern: c.Pattern}, true
+	}
+
+	return constraint{
+		pattern: c.Pattern,
+		parse:   func(value string) (any, error) { return c.Parse(value, arg) },
+	}, true
+}
+
+// checkConstraints panics if a parameter of the path or host pattern has a
+// constraint written as a type which is not known.
+func (n *Nexora) checkConstraints(path string) {
+	for _, param := range routeParams(path) {
+		if param.Constraint == "" || param.Wildcard || !isConstraintName(param.Constraint) {
+			continue
+		}
+		if _, ok := n.resolveConstraint(param.Constraint); !ok {
+			panicf("nexora: unknown constraint %q of parameter %q in %q", param.Constraint, param.Name, path)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package nexora
+
+import (
+	"errors"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newConstraintApp() *Nexora {
+	app := New()
+	app.RegisterConstraint("sku", Constraint{Pattern: `[A-Z]{3}-\d{4}`})
+	app.RegisterConstraint("date", Constraint{
+		Parse: func(value, layout string) (any, error) {
+			if layout == "" {
+				layout = time.DateOnly
+			}
+			return time.Parse(layout, value)
+		},
+	})
+	app.RegisterConstraint("iso639", Constraint{
+		Pattern: `[a-z]{2}`,
+		Parse: func(value, _ string) (any, error) {
+			if value != "en" && value != "fr" {
+				return nil, errors.New("unsupported language")
+			}
+			return value, nil
+		},
+	})
+	return app
+}
+
+func TestNexora_RegisterConstraint(t *testing.T) {
+	app := newConstraintApp()
+	app.Get("/products/{sku:sku}", func(c *Context) error {
+		return c.SendString(c.Param("sku"))
+	})
+	app.Get("/reports/{day:date(02-01-2006)}", func(c *Context) error {
+		if _, ok := c.params.typed("day").(time.Time); !ok {
+			t.Errorf("day was not parsed when the route was matched: %v", c.params)
+		}
+		day, err := ParamAs[time.Time](c, "day")
+		if err != nil {
+			return err
+		}
+		return c.SendString(day.Format(time.DateOnly))
+	})
+	app.Get("/days/{day:date}", func(c *Context) error {
+		return c.SendString(c.Param("day"))
+	})
+	app.Get("/{lang:iso639}/about", func(c *Context) error {
+		return c.SendString(c.Param("lang"))
+	})
+	app.Host("{lang:iso639}.example.com").Get("/", func(c *Context) error {
+		return c.SendString("host " + c.Param("lang"))
+	})
+
+	tests := []struct {
+		host, target string
+		code         int
+		body         string
+	}{
+		{"", "/products/ABC-1234", StatusOK, "ABC-1234"},
+		{"", "/products/abc-1234", StatusNotFound, ""},
+		{"", "/reports/31-12-2024", StatusOK, "2024-12-31"},
+		{"", "/reports/2024-12-31", StatusNotFound, ""},
+		{"", "/days/2024-12-31", StatusOK, "2024-12-31"},
+		{"", "/days/31-12-2024", StatusNotFound, ""},
+		{"", "/fr/about", StatusOK, "fr"},
+		{"", "/de/about", StatusNotFound, ""},
+		{"en.example.com", "/", StatusOK, "host en"},
+		{"de.example.com", "/", StatusNotFound, ""},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest("GET", tt.target, nil)
+		if tt.host != "" {
+			req.Host = tt.host
+		}
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+
+		if rec.Code != tt.code || (tt.body != "" && rec.Body.String() != tt.body) {
+			t.Errorf("GET %s%s = %d %q, want %d %q", tt.host, tt.target, rec.Code, rec.Body.String(), tt.code, tt.body)
+		}
+	}
+
+	route := app.Get("/skus/{sku:sku}", dummyHandler("sku"))
+	if _, err := route.BuildURL("sku", "ABC-1234"); err != nil {
+		t.Errorf("BuildURL() = %v", err)
+	}
+	if _, err := route.BuildURL("sku", "nope"); err == nil {
+		t.Error("BuildURL() accepted a value not matching the constraint")
+	}
+
+	doc, err := app.GenerateOpenAPI(OpenAPIConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if schema := doc.Paths["/skus/{sku}"]["get"].Parameters[0].Schema; schema.Type != "string" || schema.Pattern != `^(?:[A-Z]{3}-\d{4})$` {
+		t.Errorf("schema of sku = %+v", schema)
+	}
+}
+
+func TestNexora_RegisterConstraintParseOnce(t *testing.T) {
+	calls := 0
+	app := New()
+	app.RegisterConstraint("even", Constraint{
+		Pattern: `\d+`,
+		Parse: func(value, _ string) (any, error) {
+			calls++
+			n, err := strconv.Atoi(value)
+			if err == nil && n%2 != 0 {
+				err = errors.New("odd number")
+			}
+			return n, err
+		},
+	})
+	app.Host("{shard:even}.example.com").Get("/items/{id:even}", func(c *Context) error {
+		shard, _ := ParamAs[int](c, "shard")
+		id, _ := ParamAs[int](c, "id")
+		return c.SendString(strconv.Itoa(shard) + " " + strconv.Itoa(id))
+	})
+
+	req := httptest.NewRequest("GET", "/items/42", nil)
+	req.Host = "2.example.com"
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, req)
+	if rec.Body.String() != "2 42" || calls != 2 {
+		t.Errorf("GET = %q with %d calls to Parse, want %q with 2", rec.Body.String(), calls, "2 42")
+	}
+}
+
+func TestNexora_RegisterConstraintErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		register func(app *Nexora)
+		panic    string
+	}{
+		{"unknown", func(app *Nexora) { app.Get("/items/{id:sku}", dummyHandler("")) }, `unknown constraint "sku"`},
+		{"unknown with argument", func(app *Nexora) { app.Get("/items/{id:int(5)}", dummyHandler("")) }, `unknown constraint "int(5)"`},
+		{"unknown in host", func(app *Nexora) { app.Host("{tenant:sku}.example.com") }, `unknown constraint "sku"`},
+		{"invalid name", func(app *Nexora) { app.RegisterConstraint("a-b", Constraint{}) }, "invalid constraint name"},
+		{"invalid pattern", func(app *Nexora) { app.RegisterConstraint("sku", Constraint{Pattern: "["}) }, "invalid pattern"},
+		{"argument without Parse", func(app *Nexora) {
+			app.RegisterConstraint("sku", Constraint{Pattern: `[A-Z]+`})
+			app.Get("/items/{id:sku(3)}", dummyHandler(""))
+		}, "takes no argument"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil || !strings.Contains(r.(string), tt.panic) {
+					t.Errorf("panic = %v, want %q", r, tt.panic)
+				}
+			}()
+			tt.register(New())
+		})
+	}
+
+	// Constraints are scoped to the instance they are registered with
+	newConstraintApp().Get("/products/{sku:sku}", dummyHandler(""))
+	defer func() {
+		if recover() == nil {
+			t.Error("constraint registered with another instance was accepted")
+		}
+	}()
+	New().Get("/products/{sku:sku}", dummyHandler(""))
+}
+
+func TestNexora_RegexConstraintStillAllowed(t *testing.T) {
+	app := New()
+	app.Get("/files/{name:[a-z]+\\.txt}", func(c *Context) error {
+		return c.SendString(c.Param("name"))
+	})
+
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, httptest.NewRequest("GET", "/files/notes.txt", nil))
+	if rec.Body.String() != "notes.txt" {
+		t.Errorf("body = %q, want %q", rec.Body.String(), "notes.txt")
+	}
+}
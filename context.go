@@ -1,6 +1,9 @@
 package nexora
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
 	"io"
 	"net"
 	"net/http"
@@ -14,21 +17,30 @@ import (
 // Context provides helper methods for accessing request data, sending responses, and controlling
 // request flow (e.g., aborting or continuing handler execution).
 type Context struct {
-	params      map[string]string // URL parameters extracted from the request path.
-	request     *http.Request     // The incoming HTTP request.
-	writer      *ResponseWriter   // Custom response writer that wraps http.ResponseWriter.
-	index       int               // Current index in the handler chain.
-	handlers    []Handler         // Middleware/handler chain.
-	nexora      *Nexora           // Reference to the Nexora app instance.
-	queryValues url.Values        // query cached
+	params      pathParams     // URL parameters extracted from the request path and host.
+	request     *http.Request  // The incoming HTTP request.
+	writer      responseWriter // Custom response writer that wraps http.ResponseWriter.
+	response    ResponseWriter // writer with the optional interfaces of the underlying writer.
+	index       int            // Current index in the handler chain.
+	handlers    []Handler      // Middleware/handler chain.
+	nexora      *Nexora        // Reference to the Nexora app instance.
+	queryValues url.Values     // query cached
+	body        []byte         // request body cached by readBody
+	bodyErr     error          // error returned while reading the body
+	bodyRead    bool           // whether the body has been read and cached
+	eventStream *EventStream   // Server-Sent Events stream started by SSE
 }
 
 // newContext creates and returns a new Context for the given Nexora instance.
 // This is typically used internally by the Nexora router.
 func newContext(nexora *Nexora) *Context {
-	return &Context{
-		nexora: nexora,
+	c := &Context{nexora: nexora}
+	if nexora != nil {
+		// Enough room for the parameters of any route, so that matching
+		// routes doesn't allocate
+		c.params = make(pathParams, 0, nexora.maxParams)
 	}
+	return c
 }
 
 // Nexora returns the parent Nexora instance associated with this context.
@@ -39,9 +51,23 @@ func (c *Context) Nexora() *Nexora {
 // init initializes the context for a new HTTP request.
 func (c *Context) init(request *http.Request, writer http.ResponseWriter) {
 	c.request = request
-	c.writer = NewResponseWriter(writer)
+	c.writer = responseWriter{ResponseWriter: writer, status: 200}
+	c.response = wrapResponseWriter(&c.writer)
 	c.index = -1
+	c.params = c.params[:0]
 	c.queryValues = nil
+	c.body = nil
+	c.bodyErr = nil
+	c.bodyRead = false
+	c.eventStream = nil
+}
+
+// release frees resources held by the context once the request is done.
+func (c *Context) release() {
+	if c.eventStream != nil {
+		c.eventStream.Close()
+		c.eventStream = nil
+	}
 }
 
 // Next executes the next handler in the middleware chain.
@@ -69,13 +95,14 @@ func (c *Context) Request() *http.Request {
 }
 
 // ResponseWriter returns the custom ResponseWriter used to send the response.
-func (c *Context) ResponseWriter() *ResponseWriter {
-	return c.writer
+func (c *Context) ResponseWriter() ResponseWriter {
+	return c.response
 }
 
 // Params returns all route parameters as a map[string]string.
+// The map is built on each call, so prefer Param to get a single value.
 func (c *Context) Params() map[string]string {
-	return c.params
+	return c.params.toMap()
 }
 
 // Param returns the value of a route parameter by name.
@@ -88,7 +115,7 @@ func (c *Context) Params() map[string]string {
 //	id := ctx.Param("id")              // returns "" if not found
 //	id := ctx.Param("id", "default")   // returns "default" if not found.
 func (c *Context) Param(name string, defaultValue ...string) string {
-	if value, ok := c.params[name]; ok {
+	if value, ok := c.params.get(name); ok {
 		return value
 	}
 	if 0 < len(defaultValue) {
@@ -112,8 +139,21 @@ func (c *Context) Param(name string, defaultValue ...string) string {
 //	    // Handle missing parameter
 //	}
 func (c *Context) ParamExists(name string) (string, bool) {
-	val, ok := c.params[name]
-	return val, ok
+	return c.params.get(name)
+}
+
+// URLFor builds the URL of the named route with Route.BuildURL.
+// An error is returned if no route has the name.
+//
+// Example:
+//
+//	url, err := c.URLFor("user", "id", 42)
+func (c *Context) URLFor(name string, pairs ...any) (string, error) {
+	route := c.nexora.Route(name)
+	if route == nil {
+		return "", fmt.Errorf("nexora: no route named %q", name)
+	}
+	return route.BuildURL(pairs...)
 }
 
 // SendString sends a plain text response with the given string content.
@@ -347,12 +387,83 @@ func (c *Context) RealIP() string {
 
 // Body returns the raw request body as []byte.
 // It reads and caches the body so multiple calls won't re-read the stream.
+// Nil is returned if the body cannot be read or exceeds Nexora.MaxBodySize.
 func (c *Context) Body() []byte {
-	data, err := io.ReadAll(c.request.Body)
+	body, err := c.readBody()
 	if err != nil {
 		return nil
 	}
-	return data
+	return body
+}
+
+// BodyReader returns a reader that replays the cached request body from the
+// start, so middleware that inspects the body and the handler that binds it
+// can both consume it. Reading fails with ErrRequestEntityTooLarge if the
+// body exceeds Nexora.MaxBodySize.
+func (c *Context) BodyReader() io.ReadCloser {
+	body, err := c.readBody()
+	if err != nil {
+		return io.NopCloser(errReader{err})
+	}
+	return io.NopCloser(bytes.NewReader(body))
+}
+
+// readBody reads the request body once, up to the configured maximum size.
+// Afterwards the body of the underlying request is replaced with a replay of
+// the cached bytes, so code using Request().Body directly still sees it.
+func (c *Context) readBody() ([]byte, error) {
+	if c.bodyRead {
+		return c.body, c.bodyErr
+	}
+	c.bodyRead = true
+
+	if c.request.Body == nil || c.request.Body == http.NoBody {
+		return nil, nil
+	}
+
+	limit := c.maxBodySize()
+	reader := io.Reader(c.request.Body)
+	if limit > 0 {
+		reader = io.LimitReader(reader, limit+1)
+	}
+
+	body, err := io.ReadAll(reader)
+	c.request.Body.Close()
+
+	var maxBytesErr *http.MaxBytesError
+	switch {
+	case errors.As(err, &maxBytesErr):
+		c.bodyErr = ErrRequestEntityTooLarge
+	case err != nil:
+		c.bodyErr = NewHTTPError(StatusBadRequest, "failed to read request body: "+err.Error())
+	case limit > 0 && int64(len(body)) > limit:
+		c.bodyErr = ErrRequestEntityTooLarge
+	default:
+		c.body = body
+	}
+
+	c.request.Body = c.BodyReader()
+	return c.body, c.bodyErr
+}
+
+// maxBodySize returns the configured maximum body size, or 0 for no limit.
+func (c *Context) maxBodySize() int64 {
+	switch {
+	case c.nexora == nil || c.nexora.MaxBodySize == 0:
+		return DefaultMaxBodySize
+	case c.nexora.MaxBodySize < 0:
+		return 0
+	}
+	return c.nexora.MaxBodySize
+}
+
+// errReader is an io.Reader that always fails with err.
+type errReader struct {
+	err error
+}
+
+func (r errReader) Read([]byte) (int, error) {
+	return 0, r.err
 }
 
 // IsAJAX returns true if the request was made via AJAX.
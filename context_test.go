@@ -1,6 +1,7 @@
 package nexora
 
 import (
+	"io"
 	"net"
 	"net/http"
 	"net/http/httptest"
@@ -86,9 +87,9 @@ func TestContext_Param(t *testing.T) {
 	ctx.init(req, rec)
 
 	// Simulate route parameters
-	ctx.params = map[string]string{
-		"id":   "42",
-		"name": "",
+	ctx.params = pathParams{
+		{key: "id", value: "42"},
+		{key: "name", value: ""},
 	}
 
 	// Test existing param
@@ -123,8 +124,8 @@ func TestContext_ParamExists(t *testing.T) {
 	ctx := newContext(nil)
 	ctx.init(req, rec)
 
-	ctx.params = map[string]string{
-		"item": "5",
+	ctx.params = pathParams{
+		{key: "item", value: "5"},
 	}
 
 	val, ok := ctx.ParamExists("item")
@@ -412,3 +413,75 @@ func TestContext_Body(t *testing.T) {
 		t.Errorf("Body() = %q, want %q", string(got), bodyContent)
 	}
 }
+
+func TestContext_BodyCached(t *testing.T) {
+	bodyContent := `{"message":"hello"}`
+
+	req := httptest.NewRequest("POST", "/submit", strings.NewReader(bodyContent))
+	rec := httptest.NewRecorder()
+
+	ctx := newContext(nil)
+	ctx.init(req, rec)
+
+	for i := 0; i < 2; i++ {
+		if got := string(ctx.Body()); got != bodyContent {
+			t.Errorf("Body() call %d = %q, want %q", i+1, got, bodyContent)
+		}
+	}
+
+	for i := 0; i < 2; i++ {
+		data, err := io.ReadAll(ctx.BodyReader())
+		if err != nil {
+			t.Fatalf("BodyReader() call %d error = %v", i+1, err)
+		}
+		if string(data) != bodyContent {
+			t.Errorf("BodyReader() call %d = %q, want %q", i+1, data, bodyContent)
+		}
+	}
+
+	// The request body is replaced with a replay of the cached bytes
+	data, _ := io.ReadAll(ctx.Request().Body)
+	if string(data) != bodyContent {
+		t.Errorf("Request().Body = %q, want %q", data, bodyContent)
+	}
+
+	var dst struct {
+		Message string `json:"message"`
+	}
+	ctx.request.Header.Set(HeaderContentType, MIMEApplicationJSON)
+	if err := ctx.BindJSON(&dst); err != nil || dst.Message != "hello" {
+		t.Errorf("BindJSON() after Body() = %+v, %v", dst, err)
+	}
+}
+
+func TestContext_BodyMaxSize(t *testing.T) {
+	tests := []struct {
+		name    string
+		max     int64
+		body    string
+		wantErr error
+	}{
+		{"within limit", 5, "hello", nil},
+		{"over limit", 4, "hello", ErrRequestEntityTooLarge},
+		{"unlimited", -1, strings.Repeat("a", DefaultMaxBodySize+1), nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/", strings.NewReader(tt.body))
+			ctx := newContext(&Nexora{MaxBodySize: tt.max})
+			ctx.init(req, httptest.NewRecorder())
+
+			_, err := io.ReadAll(ctx.BodyReader())
+			if err != tt.wantErr {
+				t.Fatalf("BodyReader() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil && ctx.Body() != nil {
+				t.Errorf("Body() = %q, want nil", ctx.Body())
+			}
+			if tt.wantErr == nil && len(ctx.Body()) != len(tt.body) {
+				t.Errorf("len(Body()) = %d, want %d", len(ctx.Body()), len(tt.body))
+			}
+		})
+	}
+}
@@ -0,0 +1,219 @@
+package nexora
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrCookieInvalid is returned when a signed or encrypted cookie cannot be
+	// authenticated with any of the configured keys, e.g. because it has been
+	// tampered with or was created with a key that has been rotated out.
+	ErrCookieInvalid = errors.New("nexora: cookie value is invalid or has been tampered with")
+
+	// ErrCookieExpired is returned when a signed or encrypted cookie is
+	// authentic but its embedded expiry time has passed.
+	ErrCookieExpired = errors.New("nexora: cookie has expired")
+
+	// ErrCookieKeysMissing is returned by the signed cookie methods when no
+	// keys are configured in Nexora.CookieKeys.
+	ErrCookieKeysMissing = errors.New("nexora: no cookie keys configured")
+)
+
+// cookieEncoding is used for signed and encrypted cookie values, as it only
+// produces characters that are allowed in cookie values.
+var cookieEncoding = base64.RawURLEncoding
+
+// Cookie returns the named cookie sent with the request.
+// http.ErrNoCookie is returned if the cookie is not present.
+func (c *Context) Cookie(name string) (*http.Cookie, error) {
+	return c.request.Cookie(name)
+}
+
+// Cookies returns all cookies sent with the request.
+func (c *Context) Cookies() []*http.Cookie {
+	return c.request.Cookies()
+}
+
+// SetCookie adds a Set-Cookie header to the response.
+// Invalid cookies may be silently dropped, as with http.SetCookie.
+func (c *Context) SetCookie(cookie *http.Cookie) {
+	http.SetCookie(c.response, cookie)
+}
+
+// ClearCookie instructs the client to delete the named cookie on the "/" path.
+func (c *Context) ClearCookie(name string) {
+	c.SetCookie(&http.Cookie{
+		Name:    name,
+		Value:   "",
+		Path:    "/",
+		MaxAge:  -1,
+		Expires: time.Unix(0, 0),
+	})
+}
+
+// SetSignedCookie adds a Set-Cookie header whose value is protected with the
+// first key in Nexora.CookieKeys.
+//
+// The value is signed with HMAC-SHA256, or encrypted with AES-GCM when
+// Nexora.EncryptCookies is enabled. The expiry derived from MaxAge or Expires
+// is embedded in the protected value, so an expired cookie replayed by the
+// client is rejected by SignedCookie with ErrCookieExpired.
+func (c *Context) SetSignedCookie(cookie *http.Cookie) error {
+	keys, encrypt := c.cookieConfig()
+	if len(keys) == 0 {
+		return ErrCookieKeysMissing
+	}
+
+	var expires time.Time
+	switch {
+	case cookie.MaxAge > 0:
+		expires = time.Now().Add(time.Duration(cookie.MaxAge) * time.Second)
+	case !cookie.Expires.IsZero():
+		expires = cookie.Expires
+	}
+
+	value, err := encodeCookieValue(keys[0], encrypt, cookie.Name, cookie.Value, expires)
+	if err != nil {
+		return err
+	}
+
+	signed := *cookie
+	signed.Value = value
+	c.SetCookie(&signed)
+	return nil
+}
+
+// SignedCookie returns the value of the named cookie that was set with
+// SetSignedCookie. Every key in Nexora.CookieKeys is tried, so cookies issued
+// before a key rotation remain readable while their key is still configured.
+//
+// http.ErrNoCookie is returned if the cookie is not present, ErrCookieInvalid
+// if it cannot be authenticated and ErrCookieExpired if it has expired.
+func (c *Context) SignedCookie(name string) (string, error) {
+	keys, encrypt := c.cookieConfig()
+	if len(keys) == 0 {
+		return "", ErrCookieKeysMissing
+	}
+
+	cookie, err := c.request.Cookie(name)
+	if err != nil {
+		return "", err
+	}
+
+	for _, key := range keys {
+		value, err := decodeCookieValue(key, encrypt, name, cookie.Value, time.Now())
+		if errors.Is(err, ErrCookieInvalid) {
+			continue
+		}
+		return value, err
+	}
+
+	return "", ErrCookieInvalid
+}
+
+func (c *Context) cookieConfig() ([][]byte, bool) {
+	if c.nexora == nil {
+		return nil, false
+	}
+	return c.nexora.CookieKeys, c.nexora.EncryptCookies
+}
+
+// encodeCookieValue protects value with key. The protected payload is the
+// expiry as unix seconds (0 for none) followed by the value. The cookie name
+// is authenticated as well, so values cannot be moved between cookies.
+func encodeCookieValue(key []byte, encrypt bool, name, value string, expires time.Time) (string, error) {
+	payload := make([]byte, 8, 8+len(value))
+	if !expires.IsZero() {
+		binary.BigEndian.PutUint64(payload, uint64(expires.Unix()))
+	}
+	payload = append(payload, value...)
+
+	if encrypt {
+		aead, err := cookieAEAD(key)
+		if err != nil {
+			return "", err
+		}
+		nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(payload)+aead.Overhead())
+		if _, err := rand.Read(nonce); err != nil {
+			return "", err
+		}
+		return cookieEncoding.EncodeToString(aead.Seal(nonce, nonce, payload, []byte(name))), nil
+	}
+
+	return cookieEncoding.EncodeToString(payload) + "." + cookieEncoding.EncodeToString(cookieMAC(key, name, payload)), nil
+}
+
+// decodeCookieValue authenticates a value created by encodeCookieValue and
+// returns the original value.
+func decodeCookieValue(key []byte, encrypt bool, name, token string, now time.Time) (string, error) {
+	var payload []byte
+
+	if encrypt {
+		data, err := cookieEncoding.DecodeString(token)
+		if err != nil {
+			return "", ErrCookieInvalid
+		}
+		aead, err := cookieAEAD(key)
+		if err != nil {
+			return "", err
+		}
+		if len(data) < aead.NonceSize() {
+			return "", ErrCookieInvalid
+		}
+		payload, err = aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], []byte(name))
+		if err != nil {
+			return "", ErrCookieInvalid
+		}
+	} else {
+		encoded, sig, ok := strings.Cut(token, ".")
+		if !ok {
+			return "", ErrCookieInvalid
+		}
+		var err error
+		if payload, err = cookieEncoding.DecodeString(encoded); err != nil {
+			return "", ErrCookieInvalid
+		}
+		mac, err := cookieEncoding.DecodeString(sig)
+		if err != nil || !hmac.Equal(mac, cookieMAC(key, name, payload)) {
+			return "", ErrCookieInvalid
+		}
+	}
+
+	if len(payload) < 8 {
+		return "", ErrCookieInvalid
+	}
+	if exp := binary.BigEndian.Uint64(payload); exp != 0 && now.Unix() >= int64(exp) {
+		return "", ErrCookieExpired
+	}
+
+	return string(payload[8:]), nil
+}
+
+func cookieMAC(key []byte, name string, payload []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(name))
+	mac.Write([]byte{0})
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// cookieAEAD returns AES-256-GCM keyed with a key derived from key, so keys
+// of any length can be used.
+func cookieAEAD(key []byte) (cipher.AEAD, error) {
+	derived := sha256.Sum256(append([]byte("nexora cookie encryption\x00"), key...))
+	block, err := aes.NewCipher(derived[:])
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,158 @@
+package nexora
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// roundTripCookie sets a signed cookie on one request and reads it back on another.
+func roundTripCookie(t *testing.T, n *Nexora, set *http.Cookie, mutate func(*http.Cookie)) (string, error) {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	ctx := newContext(n)
+	ctx.init(httptest.NewRequest(http.MethodGet, "/", nil), rec)
+	if err := ctx.SetSignedCookie(set); err != nil {
+		t.Fatalf("SetSignedCookie() error = %v", err)
+	}
+
+	cookies := rec.Result().Cookies()
+	if len(cookies) != 1 {
+		t.Fatalf("expected 1 cookie, got %d", len(cookies))
+	}
+	if mutate != nil {
+		mutate(cookies[0])
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.AddCookie(cookies[0])
+	ctx = newContext(n)
+	ctx.init(req, httptest.NewRecorder())
+	return ctx.SignedCookie(set.Name)
+}
+
+func TestContext_CookieAndSetCookie(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.AddCookie(&http.Cookie{Name: "theme", Value: "dark"})
+	rec := httptest.NewRecorder()
+
+	ctx := newContext(nil)
+	ctx.init(req, rec)
+
+	cookie, err := ctx.Cookie("theme")
+	if err != nil || cookie.Value != "dark" {
+		t.Errorf("Cookie(theme) = %v, %v", cookie, err)
+	}
+	if _, err := ctx.Cookie("missing"); !errors.Is(err, http.ErrNoCookie) {
+		t.Errorf("Cookie(missing) error = %v, want http.ErrNoCookie", err)
+	}
+	if len(ctx.Cookies()) != 1 {
+		t.Errorf("Cookies() = %v", ctx.Cookies())
+	}
+
+	ctx.SetCookie(&http.Cookie{Name: "session", Value: "abc", HttpOnly: true})
+	ctx.ClearCookie("theme")
+
+	got := rec.Header().Values(HeaderSetCookie)
+	if len(got) != 2 || got[0] != "session=abc; HttpOnly" || !strings.Contains(got[1], "theme=; Path=/; Expires=") || !strings.Contains(got[1], "Max-Age=0") {
+		t.Errorf("Set-Cookie = %q", got)
+	}
+}
+
+func TestContext_SignedCookie(t *testing.T) {
+	for _, encrypt := range []bool{false, true} {
+		n := New()
+		n.CookieKeys = [][]byte{[]byte("secret-key")}
+		n.EncryptCookies = encrypt
+
+		value, err := roundTripCookie(t, n, &http.Cookie{Name: "uid", Value: "42", MaxAge: 60}, nil)
+		if err != nil || value != "42" {
+			t.Errorf("encrypt=%v: SignedCookie() = %q, %v", encrypt, value, err)
+		}
+
+		_, err = roundTripCookie(t, n, &http.Cookie{Name: "uid", Value: "42"}, func(c *http.Cookie) {
+			b := []byte(c.Value)
+			i := len(b) / 2
+			if b[i] == 'A' {
+				b[i] = 'B'
+			} else {
+				b[i] = 'A'
+			}
+			c.Value = string(b)
+		})
+		if !errors.Is(err, ErrCookieInvalid) {
+			t.Errorf("encrypt=%v: tampered SignedCookie() error = %v, want ErrCookieInvalid", encrypt, err)
+		}
+
+		_, err = roundTripCookie(t, n, &http.Cookie{Name: "uid", Value: "42"}, func(c *http.Cookie) {
+			c.Name = "admin"
+		})
+		if !errors.Is(err, http.ErrNoCookie) {
+			t.Errorf("encrypt=%v: renamed SignedCookie() error = %v, want http.ErrNoCookie", encrypt, err)
+		}
+	}
+}
+
+func TestContext_SignedCookieEncryptedValueIsOpaque(t *testing.T) {
+	n := New()
+	n.CookieKeys = [][]byte{[]byte("k")}
+	n.EncryptCookies = true
+
+	rec := httptest.NewRecorder()
+	ctx := newContext(n)
+	ctx.init(httptest.NewRequest(http.MethodGet, "/", nil), rec)
+	if err := ctx.SetSignedCookie(&http.Cookie{Name: "s", Value: "plain-text-value"}); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(rec.Header().Get(HeaderSetCookie), "plain-text-value") {
+		t.Error("encrypted cookie exposes its value")
+	}
+}
+
+func TestContext_SignedCookieKeyRotation(t *testing.T) {
+	n := New()
+	n.CookieKeys = [][]byte{[]byte("old")}
+
+	value, err := roundTripCookie(t, n, &http.Cookie{Name: "uid", Value: "7"}, func(c *http.Cookie) {
+		n.CookieKeys = [][]byte{[]byte("new"), []byte("old")}
+	})
+	if err != nil || value != "7" {
+		t.Errorf("SignedCookie() after rotation = %q, %v", value, err)
+	}
+
+	_, err = roundTripCookie(t, n, &http.Cookie{Name: "uid", Value: "7"}, func(c *http.Cookie) {
+		n.CookieKeys = [][]byte{[]byte("newer")}
+	})
+	if !errors.Is(err, ErrCookieInvalid) {
+		t.Errorf("SignedCookie() with retired key error = %v, want ErrCookieInvalid", err)
+	}
+}
+
+func TestDecodeCookieValue_Expired(t *testing.T) {
+	key := []byte("k")
+	for _, encrypt := range []bool{false, true} {
+		token, err := encodeCookieValue(key, encrypt, "uid", "1", time.Now().Add(-time.Minute))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := decodeCookieValue(key, encrypt, "uid", token, time.Now()); !errors.Is(err, ErrCookieExpired) {
+			t.Errorf("encrypt=%v: decodeCookieValue() error = %v, want ErrCookieExpired", encrypt, err)
+		}
+	}
+}
+
+func TestContext_SignedCookieWithoutKeys(t *testing.T) {
+	ctx := newContext(New())
+	ctx.init(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder())
+
+	if err := ctx.SetSignedCookie(&http.Cookie{Name: "a", Value: "b"}); !errors.Is(err, ErrCookieKeysMissing) {
+		t.Errorf("SetSignedCookie() error = %v, want ErrCookieKeysMissing", err)
+	}
+	if _, err := ctx.SignedCookie("a"); !errors.Is(err, ErrCookieKeysMissing) {
+		t.Errorf("SignedCookie() error = %v, want ErrCookieKeysMissing", err)
+	}
+}
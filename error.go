@@ -23,6 +23,13 @@ func (e *HTTPError) Error() string {
 	return fmt.Sprintf("HTTP %d: %s", e.StatusCode, e.Message)
 }
 
+// Is reports whether target is an *HTTPError with the same status code, so
+// that errors.Is(err, ErrBadRequest) holds for any error with status 400.
+func (e *HTTPError) Is(target error) bool {
+	t, ok := target.(*HTTPError)
+	return ok && t.StatusCode == e.StatusCode
+}
+
 var (
 	ErrBadRequest                    = NewHTTPError(StatusBadRequest, "Bad Request")
 	ErrUnauthorized                  = NewHTTPError(StatusUnauthorized, "Unauthorized")
@@ -7,4 +7,7 @@ toolchain go1.24.4
 require (
 	github.com/savsgio/gotils v0.0.0-20250408102913-196191ec6287
 	github.com/valyala/bytebufferpool v1.0.0
+	golang.org/x/net v0.42.0
 )
+
+require golang.org/x/text v0.27.0 // indirect
@@ -9,6 +9,7 @@ type RouteGroup struct {
 	prefix   string    // The prefix for the route group, used to create a common path for all routes in this group.
 	nexora   *Nexora   // A reference to the Nexora instance that created this group, allowing access to shared resources and settings.
 	handlers []Handler // A slice of handlers that will be applied to all routes in this group.
+	host     *host     // The host the routes of this group are restricted to, if any.
 }
 
 // newRouteGroup creates a new RouteGroup with the specified prefix and handlers.
@@ -33,7 +34,9 @@ func (g *RouteGroup) Group(prefix string, handlers ...Handler) *RouteGroup {
 		handlers = make([]Handler, len(g.handlers))
 		copy(handlers, g.handlers)
 	}
-	return newRouteGroup(g.nexora, g.prefix+prefix, handlers)
+	group := newRouteGroup(g.nexora, g.prefix+prefix, handlers)
+	group.host = g.host
+	return group
 }
 
 // Get registers a new GET route with the specified path and handlers.
@@ -84,7 +87,8 @@ func (g *RouteGroup) Connect(path string, handler ...Handler) *Route {
 // add registers a new route with the specified method and path, combining the group's handlers with the provided handlers.
 func (g *RouteGroup) add(method, path string, handler []Handler) *Route {
 	r := g.newRoute(method, path)
-	g.nexora.Handle(method, g.prefix+r.path, combineHandlers(g.handlers, handler)...)
+	r.handlers = combineHandlers(g.handlers, handler)
+	g.nexora.register(r)
 	return r
 }
 
@@ -94,7 +98,7 @@ func (g *RouteGroup) newRoute(method, path string) *Route {
 		group:    g,
 		method:   method,
 		path:     path,
-		template: buildURLTemplate(path),
+		template: buildURLTemplate(g.prefix + path),
 	}
 }
 
@@ -112,6 +112,9 @@ const (
 	// HeaderIfUnmodifiedSince makes the request conditional: it will only be successful if the resource has not been modified since the given date.
 	HeaderIfUnmodifiedSince = "If-Unmodified-Since"
 
+	// HeaderLastEventID carries the ID of the last Server-Sent Event received by the client.
+	HeaderLastEventID = "Last-Event-ID"
+
 	// HeaderLastModified indicates the date and time the resource was last modified.
 	HeaderLastModified = "Last-Modified"
 
@@ -250,6 +253,21 @@ const (
 	// HeaderSaveData indicates user preference for reduced data usage.
 	HeaderSaveData = "Save-Data"
 
+	// HeaderSecWebSocketAccept confirms the WebSocket handshake key sent by the client.
+	HeaderSecWebSocketAccept = "Sec-WebSocket-Accept"
+
+	// HeaderSecWebSocketExtensions negotiates WebSocket extensions such as permessage-deflate.
+	HeaderSecWebSocketExtensions = "Sec-WebSocket-Extensions"
+
+	// HeaderSecWebSocketKey carries the WebSocket handshake key of the client.
+	HeaderSecWebSocketKey = "Sec-WebSocket-Key"
+
+	// HeaderSecWebSocketProtocol negotiates the WebSocket subprotocol.
+	HeaderSecWebSocketProtocol = "Sec-WebSocket-Protocol"
+
+	// HeaderSecWebSocketVersion specifies the WebSocket protocol version.
+	HeaderSecWebSocketVersion = "Sec-WebSocket-Version"
+
 	// HeaderSourceMap indicates where source maps are located for debugging.
 	HeaderSourceMap = "SourceMap"
 
@@ -0,0 +1,238 @@
+package nexora
+
+import (
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// host holds the routing trees of a host registered with Host.
+type host struct {
+	pattern string
+	regex   *regexp.Regexp // Nil if the pattern has no parameters
+	keys    []string       // Names of the parameters, matched by the groups p0, p1, ...
+	parsers []func(string) (any, error)
+	trees   []*tree
+}
+
+// Host returns a RouteGroup whose routes only match requests sent to hosts
+// matching the pattern. The pattern is a host name without port, which may
+// contain parameters, e.g. "{tenant}.example.com". Parameters match a single
+// label by default, or the given constraint as in paths, and their values are
+// available through Context.Param. A {name:*} parameter matches any number of
+// labels. Host names are matched case-insensitively and the port of the
+// request is ignored.
+//
+// Hosts without parameters are matched before hosts with parameters, which
+// are tried in the order they were registered. Only the routes of the first
+// matching host are considered. Requests that don't match any of its routes
+// are routed with the routes registered outside of Host, which also handle
+// redirects, OPTIONS requests and 405 responses.
+//
+// If no handler is provided, the group inherits the handlers registered with
+// Use at the time Host is called. Calling Host again with the same pattern
+// adds routes to the same host.
+//
+// Example:
+//
+//	api := app.Host("api.example.com")
+//	api.Get("/users", listUsers)
+//
+//	tenants := app.Host("{tenant}.example.com")
+//	tenants.Get("/", func(c *nexora.Context) error {
+//		return c.SendString("Welcome, " + c.Param("tenant"))
+//	})
+func (n *Nexora) Host(pattern string, handlers ...Handler) *RouteGroup {
+	pattern = strings.ToLower(strings.TrimSuffix(pattern, "."))
+
+	h, ok := n.hosts[pattern]
+	if !ok {
+		n.checkConstraints(pattern)
+		h = newHost(pattern, n.resolveConstraint)
+		if n.hosts == nil {
+			n.hosts = make(map[string]*host)
+		}
+		n.hosts[pattern] = h
+		if h.regex != nil {
+			n.hostPatterns = append(n.hostPatterns, h)
+		}
+	}
+
+	group := n.RouteGroup.Group("", handlers...)
+	group.host = h
+	return group
+}
+
+// newHost parses the host pattern, resolving constraints with resolve.
+// It panics if the pattern is empty or has an invalid parameter.
+func newHost(pattern string, resolve constraintResolver) *host {
+	if pattern == "" || strings.Contains(pattern, "/") {
+		panicf("nexora: invalid host pattern %q", pattern)
+	}
+
+	h := &host{pattern: pattern}
+	if !strings.Contains(pattern, "{") {
+		return h
+	}
+
+	var expr strings.Builder
+	expr.WriteByte('^')
+
+	last := 0
+	for start := 0; start < len(pattern); start++ {
+		if pattern[start] != '{' {
+			continue
+		}
+
+		end := paramEnd(pattern, start)
+		if end == len(pattern) {
+			panicf("nexora: unclosed parameter in host pattern %q", pattern)
+		}
+		param := routeParams(pattern[start : end+1])[0]
+		if param.Name == "" || param.Optional {
+			panicf("nexora: invalid parameter in host pattern %q", pattern)
+		}
+
+		value := constraint{pattern: `[^.]+`}
+		switch {
+		case param.Wildcard:
+			value.pattern = `.+`
+		case param.Constraint != "":
+			c, ok := resolve(param.Constraint)
+			switch {
+			case !ok:
+				value.pattern = param.Constraint
+			case c.pattern != "":
+				value.pattern = c.pattern
+			}
+			value.parse = c.parse
+		}
+
+		expr.WriteString(regexp.QuoteMeta(pattern[last:start]))
+		expr.WriteString("(?P<p" + strconv.Itoa(len(h.keys)) + ">" + value.pattern + ")")
+		h.keys = append(h.keys, param.Name)
+		h.parsers = append(h.parsers, value.parse)
+
+		start = end
+		last = end + 1
+	}
+
+	expr.WriteString(regexp.QuoteMeta(pattern[last:]))
+	expr.WriteByte('$')
+
+	h.regex = regexp.MustCompile(expr.String())
+	return h
+}
+
+// match reports whether the host name matches the pattern, and appends the
+// values of its parameters to params, with the values parsed by their typed
+// constraints.
+func (h *host) match(name string, params *pathParams) bool {
+	if h.regex == nil {
+		return name == h.pattern
+	}
+
+	m := h.regex.FindStringSubmatch(name)
+	if m == nil {
+		return false
+	}
+
+	start := len(*params)
+	for i, key := range h.keys {
+		param := pathParam{key: key, value: m[h.regex.SubexpIndex("p"+strconv.Itoa(i))]}
+		if h.parsers[i] != nil {
+			typed, err := h.parsers[i](param.value)
+			if err != nil {
+				*params = (*params)[:start]
+				return false
+			}
+			param.typed = typed
+		}
+		*params = append(*params, param)
+	}
+	return true
+}
+
+// paramCount returns the number of parameters of the host pattern, or 0 if
+// the host is nil.
+func (h *host) paramCount() int {
+	if h == nil {
+		return 0
+	}
+	return len(h.keys)
+}
+
+// tree returns the tree of the method, or nil if the host has no routes for it.
+func (h *host) tree(methodIndex int) *tree {
+	if methodIndex < 0 || methodIndex >= len(h.trees) {
+		return nil
+	}
+	return h.trees[methodIndex]
+}
+
+// treeFor returns the tree of the method, creating it if needed.
+func (h *host) treeFor(methodIndex int, mutable bool, constraints constraintResolver) *tree {
+	for len(h.trees) <= methodIndex {
+		h.trees = append(h.trees, nil)
+	}
+	if h.trees[methodIndex] == nil {
+		h.trees[methodIndex] = newTree()
+		h.trees[methodIndex].Mutable = mutable
+		h.trees[methodIndex].constraints = constraints
+	}
+	return h.trees[methodIndex]
+}
+
+// matchHost returns the host matching the host of a request, and appends
+// the values of its parameters to params. Hosts without parameters take
+// precedence.
+func (n *Nexora) matchHost(requestHost string, params *pathParams) *host {
+	name := requestHost
+	if hostname, _, err := net.SplitHostPort(requestHost); err == nil {
+		name = hostname
+	}
+	name = strings.ToLower(strings.TrimSuffix(name, "."))
+
+	if h, ok := n.hosts[name]; ok && h.regex == nil {
+		return h
+	}
+	for _, h := range n.hostPatterns {
+		if h.match(name, params) {
+			return h
+		}
+	}
+	return nil
+}
+
+// serveHost serves the request with the routes of the matching host.
+// It reports whether one of them handled the request.
+func (n *Nexora) serveHost(c *Context, methodIndex int, path string) bool {
+	// The parameters of the path are appended after those of the host, so
+	// they take precedence.
+	h := n.matchHost(c.request.Host, &c.params)
+	if h == nil {
+		return false
+	}
+
+	for _, index := range []int{methodIndex, n.methodIndexOf(MethodWild)} {
+		tree := h.tree(index)
+		if tree == nil {
+			continue
+		}
+
+		handlers, _ := tree.lookup(path, &c.params)
+		if handlers == nil {
+			continue
+		}
+
+		c.handlers = handlers
+		if err := c.Next(); err != nil {
+			n.handleError(c, err)
+		}
+		return true
+	}
+
+	c.params = c.params[:0]
+	return false
+}
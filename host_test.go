@@ -0,0 +1,69 @@
+package nexora
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNexora_Host(t *testing.T) {
+	app := New()
+
+	app.Get("/", dummyHandler("default"))
+	app.Get("/shared", dummyHandler("default shared"))
+	app.Host("api.example.com").Get("/", dummyHandler("api"))
+	app.Host("{tenant}.example.com").Get("/", func(c *Context) error {
+		return c.SendString("tenant " + c.Param("tenant"))
+	})
+	app.Host("{tenant}.example.com").Group("/users").Get("/{id:int}", func(c *Context) error {
+		return c.SendString(c.Param("tenant") + "/" + c.Param("id"))
+	})
+	app.Host("{region:[a-z]{2}}.{env:*}.example.org").Get("/", func(c *Context) error {
+		return c.SendString(c.Param("region") + " " + c.Param("env"))
+	})
+
+	tests := []struct {
+		host, path string
+		code       int
+		body       string
+	}{
+		{"api.example.com", "/", StatusOK, "api"},
+		{"API.Example.com:8080", "/", StatusOK, "api"},
+		{"acme.example.com", "/", StatusOK, "tenant acme"},
+		{"acme.example.com", "/users/7", StatusOK, "acme/7"},
+		{"acme.example.com", "/shared", StatusOK, "default shared"},
+		{"api.example.com", "/users/7", StatusNotFound, ""},
+		{"a.b.example.com", "/", StatusOK, "default"},
+		{"eu.staging.internal.example.org", "/", StatusOK, "eu staging.internal"},
+		{"europe.staging.example.org", "/", StatusOK, "default"},
+		{"example.net", "/", StatusOK, "default"},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(MethodGet, tt.path, nil)
+		req.Host = tt.host
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+
+		if rec.Code != tt.code || (tt.body != "" && rec.Body.String() != tt.body) {
+			t.Errorf("%s%s = %d %q, want %d %q", tt.host, tt.path, rec.Code, rec.Body.String(), tt.code, tt.body)
+		}
+	}
+
+	routes := app.Routes()
+	if routes[2].Host != "api.example.com" || routes[2].Path != "/" {
+		t.Errorf("route info = %+v", routes[2])
+	}
+}
+
+func TestNexora_HostInvalid(t *testing.T) {
+	for _, pattern := range []string{"", "example.com/api", "{}.example.com", "{tenant?}.example.com", "{tenant.example.com"} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Host(%q) did not panic", pattern)
+				}
+			}()
+			New().Host(pattern)
+		}()
+	}
+}
@@ -0,0 +1,227 @@
+package nexora
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Matcher reports whether a request matches a route, in addition to its
+// method and path. Matchers are attached to a route with Route.Match.
+type Matcher func(r *http.Request) bool
+
+// routeMatcher is a matcher with the error returned if no route matches
+// because of it.
+type routeMatcher struct {
+	match Matcher
+	err   *HTTPError
+}
+
+// routeSet holds the routes sharing a method and path. As long as it has a
+// single route without matchers, the handlers of the route are stored in the
+// routing trees. Otherwise the handler of the set is stored instead, and runs
+// the handlers of the first route whose matchers all match the request.
+type routeSet struct {
+	routes   []*Route
+	handlers []Handler
+	paths    []treePath // Paths under which the set was added to the routing trees
+}
+
+// treePath is a path added to a routing tree.
+type treePath struct {
+	tree *tree
+	path string
+}
+
+func newRouteSet() *routeSet {
+	return &routeSet{}
+}
+
+// dispatch stores the handler of the set in the routing trees, in place of
+// the handlers of its first route, so that the matchers are checked.
+func (s *routeSet) dispatch() {
+	if s.handlers != nil {
+		return
+	}
+	s.handlers = []Handler{s.serve}
+	for _, p := range s.paths {
+		p.tree.Set(p.path, s.handlers)
+	}
+}
+
+// key returns the key of the route set of the route.
+func (r *Route) key() string {
+	return r.host() + " " + r.method + " " + r.Path()
+}
+
+// serve runs the handlers of the first matching route. If no route matches,
+// the error of the failed matchers is returned, preferring
+// ErrUnsupportedMediaType over ErrNotAcceptable over ErrNotFound.
+func (s *routeSet) serve(c *Context) error {
+	var err *HTTPError
+	for _, r := range s.routes {
+		failed := r.failedMatcher(c.request)
+		if failed == nil {
+			c.handlers = r.handlers
+			c.index = -1
+			return c.Next()
+		}
+		if err == nil || matcherErrorPriority(failed.err) > matcherErrorPriority(err) {
+			err = failed.err
+		}
+	}
+	return err
+}
+
+// matcherErrorPriority returns the priority of an error of a failed matcher.
+func matcherErrorPriority(err *HTTPError) int {
+	switch err {
+	case ErrUnsupportedMediaType:
+		return 2
+	case ErrNotAcceptable:
+		return 1
+	}
+	return 0
+}
+
+// failedMatcher returns the first matcher of the route that does not match
+// the request, or nil if all of them match.
+func (r *Route) failedMatcher(req *http.Request) *routeMatcher {
+	for i := range r.matchers {
+		if !r.matchers[i].match(req) {
+			return &r.matchers[i]
+		}
+	}
+	return nil
+}
+
+// addMatcher attaches a matcher to the route, or to its nested routes.
+func (r *Route) addMatcher(m routeMatcher) *Route {
+	if len(r.routes) > 0 {
+		for _, route := range r.routes {
+			route.addMatcher(m)
+		}
+		return r
+	}
+	r.matchers = append(r.matchers, m)
+	if set, ok := r.group.nexora.routeSets[r.key()]; ok {
+		set.dispatch()
+	}
+	return r
+}
+
+// Match restricts the route to requests for which fn returns true.
+//
+// Several routes can be registered for the same method and path if they
+// have matchers. They are tried in the order they were registered, and the
+// first route whose matchers all match the request handles it. Registering a
+// route after a route without matchers for the same method and path panics,
+// as it would never be matched.
+//
+// If no route matches, ErrUnsupportedMediaType is returned if a ContentType
+// matcher failed, ErrNotAcceptable if a matcher on the Accept header failed,
+// and ErrNotFound otherwise. The request is answered with 404 Not Found then,
+// not 405 Method Not Allowed, even if routes of other methods match the path.
+//
+// Example:
+//
+//	app.Get("/items", listItemsV2).Headers("Accept", "application/vnd.acme.v2+json")
+//	app.Get("/items", listItems)
+func (r *Route) Match(fn Matcher) *Route {
+	return r.addMatcher(routeMatcher{match: fn, err: ErrNotFound})
+}
+
+// Headers restricts the route to requests with the given headers, given in
+// the sequence of name1, value1, name2, value2, and so on. A header matches
+// if one of its comma separated elements, without parameters, equals the
+// value case-insensitively. An empty value only requires the header to be
+// present. See Match for how routes with matchers are selected.
+func (r *Route) Headers(pairs ...string) *Route {
+	if len(pairs)%2 != 0 {
+		panicf("nexora: odd number of arguments to Headers of route %s", r)
+	}
+
+	for i := 0; i < len(pairs); i += 2 {
+		name, value := http.CanonicalHeaderKey(pairs[i]), pairs[i+1]
+
+		err := ErrNotFound
+		if name == HeaderAccept {
+			err = ErrNotAcceptable
+		}
+
+		r.addMatcher(routeMatcher{
+			match: func(req *http.Request) bool {
+				return headerHasElement(req.Header.Values(name), value)
+			},
+			err: err,
+		})
+	}
+	return r
+}
+
+// Queries restricts the route to requests with the given query parameters,
+// given in the sequence of name1, value1, name2, value2, and so on. An empty
+// value only requires the parameter to be present. See Match for how routes
+// with matchers are selected.
+func (r *Route) Queries(pairs ...string) *Route {
+	if len(pairs)%2 != 0 {
+		panicf("nexora: odd number of arguments to Queries of route %s", r)
+	}
+
+	for i := 0; i < len(pairs); i += 2 {
+		name, value := pairs[i], pairs[i+1]
+
+		r.Match(func(req *http.Request) bool {
+			values, ok := req.URL.Query()[name]
+			if !ok || value == "" {
+				return ok
+			}
+			for _, v := range values {
+				if v == value {
+					return true
+				}
+			}
+			return false
+		})
+	}
+	return r
+}
+
+// ContentType restricts the route to requests whose body has one of the
+// given media types. A type ending with "/*", like "text/*", matches all its
+// subtypes. See Match for how routes with matchers are selected.
+func (r *Route) ContentType(types ...string) *Route {
+	return r.addMatcher(routeMatcher{
+		match: func(req *http.Request) bool {
+			mediaType := parseMediaType(req.Header.Get(HeaderContentType))
+			if mediaType == "" {
+				return false
+			}
+			for _, t := range types {
+				t = strings.ToLower(t)
+				if t == mediaType || strings.HasSuffix(t, "/*") && strings.HasPrefix(mediaType, t[:len(t)-1]) {
+					return true
+				}
+			}
+			return false
+		},
+		err: ErrUnsupportedMediaType,
+	})
+}
+
+// headerHasElement reports whether one of the comma separated elements of the
+// header values, without parameters, equals value case-insensitively.
+// An empty value matches any present header.
+func headerHasElement(values []string, value string) bool {
+	if value == "" {
+		return len(values) > 0
+	}
+	for _, v := range values {
+		for _, element := range strings.Split(v, ",") {
+			element, _, _ = strings.Cut(element, ";")
+			if strings.EqualFold(strings.TrimSpace(element), value) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,123 @@
+package nexora
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRoute_Matchers(t *testing.T) {
+	app := New()
+	app.Get("/items", dummyHandler("v2")).Headers("Accept", "application/vnd.acme.v2+json")
+	app.Get("/items", dummyHandler("debug")).Queries("debug", "1")
+	app.Get("/items", dummyHandler("mobile")).Match(func(r *http.Request) bool {
+		return strings.Contains(r.UserAgent(), "Mobile")
+	})
+	app.Get("/items", dummyHandler("v1"))
+
+	app.Post("/upload", dummyHandler("json")).ContentType(MIMEApplicationJSON)
+	app.Post("/upload", dummyHandler("text")).ContentType("text/*")
+
+	app.Get("/report", dummyHandler("csv")).Headers("Accept", "text/csv")
+	app.Get("/report", dummyHandler("beta")).Headers("X-Beta", "")
+
+	tests := []struct {
+		method, target string
+		header         map[string]string
+		code           int
+		body           string
+	}{
+		{"GET", "/items", nil, StatusOK, "v1"},
+		{"GET", "/items", map[string]string{"Accept": "text/html, application/vnd.acme.v2+json;q=0.9"}, StatusOK, "v2"},
+		{"GET", "/items?debug=1", nil, StatusOK, "debug"},
+		{"GET", "/items?debug=0", nil, StatusOK, "v1"},
+		{"GET", "/items", map[string]string{"User-Agent": "Mobile Safari"}, StatusOK, "mobile"},
+		{"POST", "/upload", map[string]string{"Content-Type": "application/json; charset=utf-8"}, StatusOK, "json"},
+		{"POST", "/upload", map[string]string{"Content-Type": "text/plain"}, StatusOK, "text"},
+		{"POST", "/upload", map[string]string{"Content-Type": "image/png"}, StatusUnsupportedMediaType, ""},
+		{"POST", "/upload", nil, StatusUnsupportedMediaType, ""},
+		{"GET", "/report", map[string]string{"Accept": "text/csv"}, StatusOK, "csv"},
+		{"GET", "/report", map[string]string{"X-Beta": "yes"}, StatusOK, "beta"},
+		{"GET", "/report", map[string]string{"Accept": "application/json"}, StatusNotAcceptable, ""},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, tt.target, nil)
+		for k, v := range tt.header {
+			req.Header.Set(k, v)
+		}
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+
+		if rec.Code != tt.code || (tt.body != "" && rec.Body.String() != tt.body) {
+			t.Errorf("%s %s %v = %d %q, want %d %q", tt.method, tt.target, tt.header, rec.Code, rec.Body.String(), tt.code, tt.body)
+		}
+	}
+
+	if n := len(app.Routes()); n != 8 {
+		t.Errorf("got %d routes, want 8", n)
+	}
+}
+
+func TestRoute_MatchersDispatch(t *testing.T) {
+	app := New()
+	ping := app.Get("/ping", dummyHandler("ping"))
+	report := app.Get("/report", dummyHandler("csv"))
+
+	// A single route without matchers is run directly by the tree
+	tree := app.trees[app.methodIndexOf(MethodGet)]
+	if handlers, _, _ := tree.Get("/ping"); len(handlers) == 0 || &handlers[0] != &ping.handlers[0] {
+		t.Error("the tree doesn't hold the handlers of /ping")
+	}
+
+	report.Headers("Accept", "text/csv")
+	if handlers, _, _ := tree.Get("/report"); len(handlers) == 0 || &handlers[0] == &report.handlers[0] {
+		t.Error("the tree holds the handlers of /report after a matcher was added")
+	}
+
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, httptest.NewRequest(MethodGet, "/report", nil))
+	if rec.Code != StatusNotAcceptable {
+		t.Errorf("GET /report = %d, want %d", rec.Code, StatusNotAcceptable)
+	}
+
+	// Failed matchers answer 404, not 405, even if other methods match the path
+	app.Post("/beta", dummyHandler("post"))
+	app.Get("/beta", dummyHandler("beta")).Headers("X-Beta", "1")
+	rec = httptest.NewRecorder()
+	app.ServeHTTP(rec, httptest.NewRequest(MethodGet, "/beta", nil))
+	if rec.Code != StatusNotFound {
+		t.Errorf("GET /beta = %d, want %d", rec.Code, StatusNotFound)
+	}
+
+	// A route without matchers matches all the requests for its path
+	app.Get("/report", dummyHandler("report"))
+	defer func() {
+		if rcv := recover(); rcv == nil || !strings.Contains(fmt.Sprint(rcv), "a handler is already registered for path '/report'") {
+			t.Errorf("panic = %v, want a handler already registered", rcv)
+		}
+	}()
+	app.Get("/report", dummyHandler("report again")).Headers("X-Beta", "")
+}
+
+func TestRoute_MatchersGroupHandlers(t *testing.T) {
+	var calls []string
+	app := New()
+	api := app.Group("/api", func(c *Context) error {
+		calls = append(calls, "middleware")
+		return c.Next()
+	})
+	api.Get("/ping", dummyHandler("v2")).Headers("X-Version", "2")
+	api.Get("/ping", dummyHandler("v1"))
+
+	req := httptest.NewRequest(MethodGet, "/api/ping", nil)
+	req.Header.Set("X-Version", "2")
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, req)
+
+	if rec.Body.String() != "v2" || len(calls) != 1 {
+		t.Errorf("body = %q, middleware calls = %d", rec.Body.String(), len(calls))
+	}
+}
@@ -0,0 +1,50 @@
+package nexora
+
+const (
+	// MIMEApplicationJSON is the media type for JSON documents.
+	MIMEApplicationJSON = "application/json"
+
+	// MIMEApplicationJSONCharsetUTF8 is the JSON media type with an explicit UTF-8 charset.
+	MIMEApplicationJSONCharsetUTF8 = MIMEApplicationJSON + "; " + charsetUTF8
+
+	// MIMEApplicationXML is the media type for XML documents.
+	MIMEApplicationXML = "application/xml"
+
+	// MIMEApplicationXMLCharsetUTF8 is the XML media type with an explicit UTF-8 charset.
+	MIMEApplicationXMLCharsetUTF8 = MIMEApplicationXML + "; " + charsetUTF8
+
+	// MIMETextXML is the legacy media type for XML documents.
+	MIMETextXML = "text/xml"
+
+	// MIMEApplicationJavaScript is the media type for JavaScript, used by JSONP responses.
+	MIMEApplicationJavaScript = "application/javascript"
+
+	// MIMEApplicationJavaScriptCharsetUTF8 is the JavaScript media type with an explicit UTF-8 charset.
+	MIMEApplicationJavaScriptCharsetUTF8 = MIMEApplicationJavaScript + "; " + charsetUTF8
+
+	// MIMETextHTML is the media type for HTML documents.
+	MIMETextHTML = "text/html"
+
+	// MIMETextHTMLCharsetUTF8 is the HTML media type with an explicit UTF-8 charset.
+	MIMETextHTMLCharsetUTF8 = MIMETextHTML + "; " + charsetUTF8
+
+	// MIMETextPlain is the media type for plain text.
+	MIMETextPlain = "text/plain"
+
+	// MIMETextPlainCharsetUTF8 is the plain text media type with an explicit UTF-8 charset.
+	MIMETextPlainCharsetUTF8 = MIMETextPlain + "; " + charsetUTF8
+
+	// MIMEApplicationForm is the media type for URL-encoded HTML form submissions.
+	MIMEApplicationForm = "application/x-www-form-urlencoded"
+
+	// MIMEMultipartForm is the media type for multipart HTML form submissions.
+	MIMEMultipartForm = "multipart/form-data"
+
+	// MIMETextEventStream is the media type for Server-Sent Events streams.
+	MIMETextEventStream = "text/event-stream"
+
+	// MIMEOctetStream is the media type for arbitrary binary data.
+	MIMEOctetStream = "application/octet-stream"
+)
+
+const charsetUTF8 = "charset=utf-8"
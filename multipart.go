@@ -0,0 +1,219 @@
+package nexora
+
+import (
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+)
+
+// defaultMaxMultipartMemory is the default number of bytes of a multipart
+// body that are kept in memory, the remainder is stored in temporary files.
+const defaultMaxMultipartMemory = 32 << 20
+
+// maxMultipartMemory returns the configured in-memory limit for multipart forms.
+func (c *Context) maxMultipartMemory() int64 {
+	if c.nexora != nil && c.nexora.MaxMultipartMemory > 0 {
+		return c.nexora.MaxMultipartMemory
+	}
+	return defaultMaxMultipartMemory
+}
+
+// limitUploadBody caps the request body at Nexora.MaxUploadSize, if set.
+func (c *Context) limitUploadBody() {
+	if c.nexora == nil || c.nexora.MaxUploadSize <= 0 || c.request.Body == nil {
+		return
+	}
+	if _, ok := c.request.Body.(*maxBytesBody); ok {
+		return
+	}
+	c.request.Body = &maxBytesBody{http.MaxBytesReader(c.response, c.request.Body, c.nexora.MaxUploadSize)}
+}
+
+// maxBytesBody marks a request body that is already limited by limitUploadBody.
+type maxBytesBody struct {
+	io.ReadCloser
+}
+
+// parseForm parses the URL-encoded or multipart form of the request once.
+func (c *Context) parseForm() error {
+	isMultipart := parseMediaType(c.GetHeader(HeaderContentType)) == MIMEMultipartForm
+
+	switch {
+	case c.request.MultipartForm != nil:
+		return nil
+	case c.request.Form != nil && !isMultipart:
+		return nil
+	}
+
+	// A body which was cached can't be parsed if it was too large
+	if c.bodyRead && c.bodyErr != nil {
+		return c.bodyErr
+	}
+
+	if isMultipart {
+		c.limitUploadBody()
+		return formError(c.request.ParseMultipartForm(c.maxMultipartMemory()))
+	}
+
+	// URL-encoded forms go through the body cache, so Body can still be
+	// used after the form has been parsed. The upload limit applies to them
+	// too, including when the body was already read with a larger limit.
+	c.limitUploadBody()
+	body, err := c.readBody()
+	if err != nil {
+		return err
+	}
+	if c.nexora != nil && c.nexora.MaxUploadSize > 0 && int64(len(body)) > c.nexora.MaxUploadSize {
+		return ErrRequestEntityTooLarge
+	}
+	return formError(c.request.ParseForm())
+}
+
+// formError maps errors from parsing forms to an *HTTPError.
+func formError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var maxBytesErr *http.MaxBytesError
+	switch {
+	case errors.As(err, &maxBytesErr), errors.Is(err, multipart.ErrMessageTooLarge):
+		return ErrRequestEntityTooLarge
+	case errors.Is(err, http.ErrNotMultipart):
+		return ErrUnsupportedMediaType
+	}
+	return NewHTTPError(StatusBadRequest, "invalid form data: "+err.Error())
+}
+
+// FormValue returns the first value of the named form field, from either the
+// request body or the query string, with body values taking precedence.
+// If the field is missing or the form cannot be parsed, the optional
+// defaultValue is returned, or an empty string otherwise.
+//
+// Example:
+//
+//	title := c.FormValue("title")
+//	size := c.FormValue("size", "M")
+func (c *Context) FormValue(name string, defaultValue ...string) string {
+	if err := c.parseForm(); err == nil {
+		if values := c.request.Form[name]; len(values) > 0 {
+			return values[0]
+		}
+	}
+	if len(defaultValue) > 0 {
+		return defaultValue[0]
+	}
+	return ""
+}
+
+// MultipartForm parses the multipart form of the request and returns it.
+// Up to Nexora.MaxMultipartMemory bytes are kept in memory, larger files
+// are stored in temporary files which are removed after the request.
+// ErrRequestEntityTooLarge is returned if the body exceeds Nexora.MaxUploadSize.
+func (c *Context) MultipartForm() (*multipart.Form, error) {
+	if parseMediaType(c.GetHeader(HeaderContentType)) != MIMEMultipartForm {
+		return nil, ErrUnsupportedMediaType
+	}
+	if err := c.parseForm(); err != nil {
+		return nil, err
+	}
+	return c.request.MultipartForm, nil
+}
+
+// FormFile returns the first file uploaded under the given form field.
+// http.ErrMissingFile is returned if no such file was uploaded.
+//
+// Example:
+//
+//	fh, err := c.FormFile("avatar")
+//	if err != nil {
+//	    return err
+//	}
+//	return c.SaveFile(fh, filepath.Join("uploads", filepath.Base(fh.Filename)))
+func (c *Context) FormFile(name string) (*multipart.FileHeader, error) {
+	form, err := c.MultipartForm()
+	if err != nil {
+		return nil, err
+	}
+	if files := form.File[name]; len(files) > 0 {
+		return files[0], nil
+	}
+	return nil, http.ErrMissingFile
+}
+
+// SaveFile writes the uploaded file to dst, creating or truncating it.
+//
+// The file name sent by the client must not be used as dst without
+// sanitizing it first, as it may contain path separators.
+func (c *Context) SaveFile(fh *multipart.FileHeader, dst string) error {
+	src, err := fh.Open()
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(out, src); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// MultipartReader returns a reader to stream the parts of a multipart body
+// without buffering it. It must not be combined with the other form methods.
+func (c *Context) MultipartReader() (*multipart.Reader, error) {
+	c.limitUploadBody()
+	reader, err := c.request.MultipartReader()
+	if err != nil {
+		return nil, formError(err)
+	}
+	return reader, nil
+}
+
+// EachPart streams the parts of a multipart body, calling fn for each of them
+// in order. Iteration stops at the first error returned by fn, which is then
+// returned by EachPart. ErrRequestEntityTooLarge is returned as soon as the
+// body exceeds Nexora.MaxUploadSize.
+//
+// Example:
+//
+//	err := c.EachPart(func(p *multipart.Part) error {
+//	    if p.FormName() != "file" {
+//	        return nil
+//	    }
+//	    _, err := io.Copy(bucket, p)
+//	    return err
+//	})
+func (c *Context) EachPart(fn func(part *multipart.Part) error) error {
+	reader, err := c.MultipartReader()
+	if err != nil {
+		return err
+	}
+
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return formError(err)
+		}
+
+		err = fn(part)
+		part.Close()
+		if err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				return ErrRequestEntityTooLarge
+			}
+			return err
+		}
+	}
+}
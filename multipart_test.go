@@ -0,0 +1,209 @@
+package nexora
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newMultipartRequest builds a multipart request with the given fields and files.
+func newMultipartRequest(t *testing.T, fields map[string]string, files map[string]string) *http.Request {
+	t.Helper()
+
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	for name, value := range fields {
+		if err := w.WriteField(name, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for name, content := range files {
+		fw, err := w.CreateFormFile(name, name+".txt")
+		if err != nil {
+			t.Fatal(err)
+		}
+		io.WriteString(fw, content)
+	}
+	w.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/upload?source=query", &body)
+	req.Header.Set(HeaderContentType, w.FormDataContentType())
+	return req
+}
+
+func TestContext_FormValue(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/?page=2", strings.NewReader("title=hello"))
+	req.Header.Set(HeaderContentType, MIMEApplicationForm)
+
+	ctx := newContext(New())
+	ctx.init(req, httptest.NewRecorder())
+
+	if got := ctx.FormValue("title"); got != "hello" {
+		t.Errorf("FormValue(title) = %q", got)
+	}
+	if got := ctx.FormValue("page"); got != "2" {
+		t.Errorf("FormValue(page) = %q", got)
+	}
+	if got := ctx.FormValue("missing", "x"); got != "x" {
+		t.Errorf("FormValue(missing, x) = %q", got)
+	}
+}
+
+func TestContext_FormFileAndSaveFile(t *testing.T) {
+	req := newMultipartRequest(t, map[string]string{"title": "doc"}, map[string]string{"file": "file content"})
+
+	ctx := newContext(New())
+	ctx.init(req, httptest.NewRecorder())
+
+	if got := ctx.FormValue("title"); got != "doc" {
+		t.Errorf("FormValue(title) = %q", got)
+	}
+	if got := ctx.FormValue("source"); got != "query" {
+		t.Errorf("FormValue(source) = %q", got)
+	}
+
+	fh, err := ctx.FormFile("file")
+	if err != nil {
+		t.Fatalf("FormFile() error = %v", err)
+	}
+	if fh.Filename != "file.txt" {
+		t.Errorf("Filename = %q", fh.Filename)
+	}
+
+	dst := filepath.Join(t.TempDir(), "saved.txt")
+	if err := ctx.SaveFile(fh, dst); err != nil {
+		t.Fatalf("SaveFile() error = %v", err)
+	}
+	if data, _ := os.ReadFile(dst); string(data) != "file content" {
+		t.Errorf("saved file = %q", data)
+	}
+
+	if _, err := ctx.FormFile("other"); !errors.Is(err, http.ErrMissingFile) {
+		t.Errorf("FormFile(other) error = %v, want http.ErrMissingFile", err)
+	}
+
+	form, err := ctx.MultipartForm()
+	if err != nil || len(form.File["file"]) != 1 {
+		t.Errorf("MultipartForm() = %v, %v", form, err)
+	}
+}
+
+func TestContext_MultipartFormNotMultipart(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("a=b"))
+	req.Header.Set(HeaderContentType, MIMEApplicationForm)
+
+	ctx := newContext(New())
+	ctx.init(req, httptest.NewRecorder())
+
+	if _, err := ctx.FormFile("file"); !errors.Is(err, ErrUnsupportedMediaType) {
+		t.Errorf("FormFile() error = %v, want ErrUnsupportedMediaType", err)
+	}
+}
+
+func TestContext_UploadTooLarge(t *testing.T) {
+	n := New()
+	n.MaxUploadSize = 64
+
+	req := newMultipartRequest(t, nil, map[string]string{"file": strings.Repeat("x", 1024)})
+	ctx := newContext(n)
+	ctx.init(req, httptest.NewRecorder())
+
+	if _, err := ctx.FormFile("file"); !errors.Is(err, ErrRequestEntityTooLarge) {
+		t.Errorf("FormFile() error = %v, want ErrRequestEntityTooLarge", err)
+	}
+
+	req = newMultipartRequest(t, nil, map[string]string{"file": strings.Repeat("x", 1024)})
+	ctx = newContext(n)
+	ctx.init(req, httptest.NewRecorder())
+
+	err := ctx.EachPart(func(p *multipart.Part) error {
+		_, err := io.Copy(io.Discard, p)
+		return err
+	})
+	if !errors.Is(err, ErrRequestEntityTooLarge) {
+		t.Errorf("EachPart() error = %v, want ErrRequestEntityTooLarge", err)
+	}
+}
+
+func TestContext_FormTooLarge(t *testing.T) {
+	n := New()
+	n.MaxUploadSize = 64
+
+	newRequest := func() *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("a="+strings.Repeat("x", 1024)))
+		req.Header.Set(HeaderContentType, MIMEApplicationForm)
+		return req
+	}
+
+	ctx := newContext(n)
+	ctx.init(newRequest(), httptest.NewRecorder())
+	if err := ctx.parseForm(); !errors.Is(err, ErrRequestEntityTooLarge) {
+		t.Errorf("parseForm() error = %v, want ErrRequestEntityTooLarge", err)
+	}
+	if v := ctx.FormValue("a", "default"); v != "default" {
+		t.Errorf("FormValue(a) = %q, want the default value", v)
+	}
+
+	// The limit also applies if the body was read before
+	ctx = newContext(n)
+	ctx.init(newRequest(), httptest.NewRecorder())
+	n.MaxUploadSize = 0
+	if body := ctx.Body(); len(body) != 1026 {
+		t.Fatalf("Body() = %d bytes, want 1026", len(body))
+	}
+	n.MaxUploadSize = 64
+	if err := ctx.parseForm(); !errors.Is(err, ErrRequestEntityTooLarge) {
+		t.Errorf("parseForm() after Body() error = %v, want ErrRequestEntityTooLarge", err)
+	}
+}
+
+func TestContext_MultipartAfterBodyTooLarge(t *testing.T) {
+	n := New()
+	n.MaxBodySize = 16
+
+	req := newMultipartRequest(t, map[string]string{"title": strings.Repeat("x", 64)}, nil)
+	ctx := newContext(n)
+	ctx.init(req, httptest.NewRecorder())
+
+	if body := ctx.Body(); body != nil {
+		t.Fatalf("Body() = %d bytes, want nil", len(body))
+	}
+	if err := ctx.parseForm(); !errors.Is(err, ErrRequestEntityTooLarge) {
+		t.Errorf("parseForm() error = %v, want ErrRequestEntityTooLarge", err)
+	}
+}
+
+func TestContext_EachPart(t *testing.T) {
+	req := newMultipartRequest(t, map[string]string{"title": "doc"}, map[string]string{"file": "streamed"})
+
+	ctx := newContext(New())
+	ctx.init(req, httptest.NewRecorder())
+
+	parts := map[string]string{}
+	err := ctx.EachPart(func(p *multipart.Part) error {
+		data, err := io.ReadAll(p)
+		parts[p.FormName()] = string(data)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("EachPart() error = %v", err)
+	}
+	if parts["title"] != "doc" || parts["file"] != "streamed" {
+		t.Errorf("parts = %v", parts)
+	}
+
+	stop := errors.New("stop")
+	req = newMultipartRequest(t, map[string]string{"a": "1"}, nil)
+	ctx = newContext(New())
+	ctx.init(req, httptest.NewRecorder())
+	if err := ctx.EachPart(func(*multipart.Part) error { return stop }); !errors.Is(err, stop) {
+		t.Errorf("EachPart() error = %v, want %v", err, stop)
+	}
+}
@@ -0,0 +1,236 @@
+package nexora
+
+import (
+	"mime"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// acceptSpec is a single entry of an Accept-* header, e.g. "text/html;q=0.8".
+type acceptSpec struct {
+	value string  // The value without parameters, lower-cased
+	q     float64 // Quality, between 0 and 1
+}
+
+// parseAcceptHeader parses an Accept-* header into its entries.
+// Malformed quality values are treated as 0, which makes the entry unacceptable.
+func parseAcceptHeader(header string) []acceptSpec {
+	specs := make([]acceptSpec, 0, strings.Count(header, ",")+1)
+
+	for _, part := range strings.Split(header, ",") {
+		value, params, _ := strings.Cut(part, ";")
+		value = strings.ToLower(strings.TrimSpace(value))
+		if value == "" {
+			continue
+		}
+
+		spec := acceptSpec{value: value, q: 1}
+		for _, param := range strings.Split(params, ";") {
+			key, val, _ := strings.Cut(param, "=")
+			if strings.TrimSpace(key) != "q" {
+				continue
+			}
+			q, err := strconv.ParseFloat(strings.TrimSpace(val), 64)
+			if err != nil || q < 0 || q > 1 {
+				q = 0
+			}
+			spec.q = q
+		}
+
+		specs = append(specs, spec)
+	}
+
+	return specs
+}
+
+// negotiate returns the offer that is preferred by the given Accept-* header.
+//
+// For each offer, the most specific matching entry determines its quality, as
+// described in RFC 9110 section 12.5. The offer with the highest quality wins;
+// ties are resolved in favor of the offer listed first. If the header is
+// empty, the first offer is returned. An empty string is returned if no offer
+// is acceptable.
+func negotiate(header string, offers []string, normalize func(string) string, match func(spec, offer string) int) string {
+	if len(offers) == 0 {
+		return ""
+	}
+	if strings.TrimSpace(header) == "" {
+		return offers[0]
+	}
+
+	specs := parseAcceptHeader(header)
+
+	best, bestQ := "", 0.0
+	for _, offer := range offers {
+		normalized := normalize(offer)
+
+		q, specificity := 0.0, -1
+		for _, spec := range specs {
+			if s := match(spec.value, normalized); s > specificity {
+				q, specificity = spec.q, s
+			}
+		}
+
+		if q > bestQ {
+			best, bestQ = offer, q
+		}
+	}
+
+	return best
+}
+
+// shortMediaTypes maps common short names to media types. They take
+// precedence over the system MIME table, which varies between platforms.
+var shortMediaTypes = map[string]string{
+	"json": MIMEApplicationJSON,
+	"xml":  MIMEApplicationXML,
+	"html": MIMETextHTML,
+	"text": MIMETextPlain,
+	"txt":  MIMETextPlain,
+	"csv":  "text/csv",
+	"js":   MIMEApplicationJavaScript,
+	"form": MIMEApplicationForm,
+}
+
+// normalizeMediaType maps a short offer such as "json" or "html" to its media type.
+func normalizeMediaType(offer string) string {
+	if strings.Contains(offer, "/") {
+		return parseMediaType(offer)
+	}
+	if typ, ok := shortMediaTypes[strings.ToLower(offer)]; ok {
+		return typ
+	}
+	if typ := mime.TypeByExtension("." + offer); typ != "" {
+		return parseMediaType(typ)
+	}
+	return strings.ToLower(offer)
+}
+
+// matchMediaType returns the specificity with which spec matches the media
+// type offer, or -1 if it does not match.
+func matchMediaType(spec, offer string) int {
+	if spec == "*/*" || spec == "*" {
+		return 0
+	}
+
+	specType, specSub, _ := strings.Cut(spec, "/")
+	offerType, offerSub, _ := strings.Cut(offer, "/")
+
+	switch {
+	case specType != offerType:
+		return -1
+	case specSub == "*":
+		return 1
+	case specSub == offerSub:
+		return 2
+	}
+	return -1
+}
+
+// matchToken matches simple tokens such as encodings and charsets.
+func matchToken(spec, offer string) int {
+	switch spec {
+	case offer:
+		return 1
+	case "*":
+		return 0
+	}
+	return -1
+}
+
+// matchLanguage matches language tags using basic filtering (RFC 4647),
+// so the range "en" matches both "en" and "en-US".
+func matchLanguage(spec, offer string) int {
+	switch {
+	case spec == offer:
+		return 2
+	case strings.HasPrefix(offer, spec+"-"):
+		return 1
+	case spec == "*":
+		return 0
+	}
+	return -1
+}
+
+// Accepts returns the offer that best matches the request Accept header,
+// or an empty string if none of them is acceptable.
+// Offers can be media types ("application/json") or file extensions ("json").
+// If the request has no Accept header, the first offer is returned.
+//
+// Example:
+//
+//	// Accept: text/html;q=0.9, application/json
+//	c.Accepts("html", "json") // "json"
+func (c *Context) Accepts(offers ...string) string {
+	return negotiate(c.GetHeader(HeaderAccept), offers, normalizeMediaType, matchMediaType)
+}
+
+// AcceptsEncodings returns the offer that best matches the request
+// Accept-Encoding header, or an empty string if none of them is acceptable.
+func (c *Context) AcceptsEncodings(offers ...string) string {
+	return negotiate(c.GetHeader(HeaderAcceptEncoding), offers, strings.ToLower, matchToken)
+}
+
+// AcceptsCharsets returns the offer that best matches the request
+// Accept-Charset header, or an empty string if none of them is acceptable.
+func (c *Context) AcceptsCharsets(offers ...string) string {
+	return negotiate(c.GetHeader(HeaderAcceptCharset), offers, strings.ToLower, matchToken)
+}
+
+// AcceptsLanguages returns the offer that best matches the request
+// Accept-Language header, or an empty string if none of them is acceptable.
+func (c *Context) AcceptsLanguages(offers ...string) string {
+	return negotiate(c.GetHeader(HeaderAcceptLanguage), offers, strings.ToLower, matchLanguage)
+}
+
+// Format calls the handler whose key best matches the request Accept header.
+//
+// Keys are media types or file extensions, as accepted by Accepts. Ties are
+// resolved in favor of the key that sorts first. Before the handler is called
+// the Content-Type header is set to the negotiated media type and "Accept" is
+// added to the Vary header.
+//
+// If no key is acceptable, the handler registered under the "default" key is
+// called. Without a default handler ErrNotAcceptable is returned.
+//
+// Example:
+//
+//	return c.Format(map[string]func() error{
+//	    "json": func() error { return c.JSON(200, users) },
+//	    "csv":  func() error { return c.Blob(200, "text/csv", toCSV(users)) },
+//	})
+func (c *Context) Format(handlers map[string]func() error) error {
+	offers := make([]string, 0, len(handlers))
+	for key := range handlers {
+		if key != "default" {
+			offers = append(offers, key)
+		}
+	}
+	sort.Strings(offers)
+
+	c.addVary(HeaderAccept)
+
+	if offer := c.Accepts(offers...); offer != "" {
+		c.SetContentType(normalizeMediaType(offer))
+		return handlers[offer]()
+	}
+
+	if handler, ok := handlers["default"]; ok {
+		return handler()
+	}
+
+	return ErrNotAcceptable
+}
+
+// addVary adds header to the Vary response header unless it is already listed.
+func (c *Context) addVary(header string) {
+	for _, value := range c.writer.Header().Values(HeaderVary) {
+		for _, v := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(v), header) {
+				return
+			}
+		}
+	}
+	c.AddHeader(HeaderVary, header)
+}
@@ -0,0 +1,119 @@
+package nexora
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newNegotiationContext(header, value string) (*Context, *httptest.ResponseRecorder) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	if value != "" {
+		req.Header.Set(header, value)
+	}
+	rec := httptest.NewRecorder()
+	ctx := newContext(nil)
+	ctx.init(req, rec)
+	return ctx, rec
+}
+
+func TestContext_Accepts(t *testing.T) {
+	tests := []struct {
+		accept string
+		offers []string
+		want   string
+	}{
+		{"", []string{"json", "xml"}, "json"},
+		{"application/json", []string{"xml", "json"}, "json"},
+		{"text/html;q=0.9, application/json", []string{"html", "json"}, "json"},
+		{"text/*;q=0.5, text/csv", []string{"text/plain", "text/csv"}, "text/csv"},
+		{"*/*;q=0.1, application/xml;q=0.8", []string{"json", "xml"}, "xml"},
+		{"application/json;q=0, */*", []string{"json", "csv"}, "csv"},
+		{"image/png", []string{"json", "html"}, ""},
+		{"application/json;q=abc", []string{"json"}, ""},
+		{"TEXT/HTML", []string{"text/html; charset=utf-8"}, "text/html; charset=utf-8"},
+	}
+
+	for _, tt := range tests {
+		ctx, _ := newNegotiationContext(HeaderAccept, tt.accept)
+		if got := ctx.Accepts(tt.offers...); got != tt.want {
+			t.Errorf("Accepts(%v) with %q = %q, want %q", tt.offers, tt.accept, got, tt.want)
+		}
+	}
+}
+
+func TestContext_AcceptsEncodings(t *testing.T) {
+	ctx, _ := newNegotiationContext(HeaderAcceptEncoding, "gzip;q=0.8, br, *;q=0.1")
+	if got := ctx.AcceptsEncodings("gzip", "br"); got != "br" {
+		t.Errorf("AcceptsEncodings() = %q, want %q", got, "br")
+	}
+	if got := ctx.AcceptsEncodings("zstd"); got != "zstd" {
+		t.Errorf("AcceptsEncodings() = %q, want wildcard match %q", got, "zstd")
+	}
+
+	ctx, _ = newNegotiationContext(HeaderAcceptEncoding, "gzip")
+	if got := ctx.AcceptsEncodings("br"); got != "" {
+		t.Errorf("AcceptsEncodings() = %q, want none", got)
+	}
+}
+
+func TestContext_AcceptsLanguages(t *testing.T) {
+	ctx, _ := newNegotiationContext(HeaderAcceptLanguage, "fr-CH, fr;q=0.9, en;q=0.8, *;q=0.5")
+	if got := ctx.AcceptsLanguages("en-US", "fr"); got != "fr" {
+		t.Errorf("AcceptsLanguages() = %q, want %q", got, "fr")
+	}
+	if got := ctx.AcceptsLanguages("de", "en-GB"); got != "en-GB" {
+		t.Errorf("AcceptsLanguages() = %q, want %q", got, "en-GB")
+	}
+}
+
+func TestContext_AcceptsCharsets(t *testing.T) {
+	ctx, _ := newNegotiationContext(HeaderAcceptCharset, "iso-8859-1;q=0.5, UTF-8")
+	if got := ctx.AcceptsCharsets("iso-8859-1", "utf-8"); got != "utf-8" {
+		t.Errorf("AcceptsCharsets() = %q, want %q", got, "utf-8")
+	}
+}
+
+func TestContext_Format(t *testing.T) {
+	ctx, rec := newNegotiationContext(HeaderAccept, "text/csv, application/json;q=0.5")
+
+	var called string
+	err := ctx.Format(map[string]func() error{
+		"json": func() error { called = "json"; return nil },
+		"csv":  func() error { called = "csv"; return nil },
+	})
+	if err != nil {
+		t.Fatalf("Format() error = %v", err)
+	}
+	if called != "csv" {
+		t.Errorf("Format() called %q, want %q", called, "csv")
+	}
+	if ct := rec.Header().Get(HeaderContentType); ct != "text/csv" {
+		t.Errorf("Content-Type = %q, want %q", ct, "text/csv")
+	}
+	if vary := rec.Header().Get(HeaderVary); vary != HeaderAccept {
+		t.Errorf("Vary = %q, want %q", vary, HeaderAccept)
+	}
+}
+
+func TestContext_FormatNotAcceptable(t *testing.T) {
+	ctx, _ := newNegotiationContext(HeaderAccept, "image/png")
+
+	err := ctx.Format(map[string]func() error{
+		"json": func() error { return nil },
+	})
+	if !errors.Is(err, ErrNotAcceptable) {
+		t.Errorf("Format() error = %v, want ErrNotAcceptable", err)
+	}
+
+	ctx, _ = newNegotiationContext(HeaderAccept, "image/png")
+	called := false
+	err = ctx.Format(map[string]func() error{
+		"json":    func() error { return nil },
+		"default": func() error { called = true; return nil },
+	})
+	if err != nil || !called {
+		t.Errorf("Format() error = %v, default called = %v", err, called)
+	}
+}
@@ -1,10 +1,12 @@
 package nexora
 
 import (
+	"errors"
 	"log"
 	"net/http"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/valyala/bytebufferpool"
 )
@@ -25,6 +27,10 @@ const (
 // MethodWild wild HTTP method
 const MethodWild = "*"
 
+// DefaultMaxBodySize is the default maximum size of a request body read by
+// Context.Body, Context.BodyReader and the Bind methods.
+const DefaultMaxBodySize = 4 << 20
+
 var questionMark = byte('?')
 
 type Handler func(c *Context) error
@@ -34,7 +40,15 @@ type Nexora struct {
 	treeMutable        bool
 	customMethodsIndex map[string]int
 	registeredPaths    map[string][]string
-	namedRoutes        map[string]*Route // Maps route names to paths
+	namedRoutes        map[string]*Route      // Maps route names to paths
+	routes             []*Route               // Registered routes, in registration order
+	routeSets          map[string]*routeSet   // Routes sharing a host, method and path
+	routePaths         map[string][]routePath // Paths added to the routing trees, by host and method
+	hosts              map[string]*host       // Hosts registered with Host, by pattern
+	hostPatterns       []*host                // Hosts with parameters, in registration order
+	decoders           map[string]Decoder     // Body decoders registered by media type
+	constraints        map[string]Constraint  // Parameter types registered with RegisterConstraint
+	maxParams          int                    // Maximum number of parameters of a route, including its host
 
 	RouteGroup // Default route group for new routes
 
@@ -97,6 +111,63 @@ type Nexora struct {
 
 	ErrorHandler func(c *Context, err error) error
 
+	// Function used by Context.Bind and Context.Validate to validate
+	// bound values. If it is not set, the package level Validate is used.
+	Validator func(v any) error
+
+	// Keys used by Context.SetSignedCookie and Context.SignedCookie.
+	// The first key protects new cookies, while every key is tried when a
+	// cookie is read. Keys can therefore be rotated by prepending a new key
+	// and removing the old one once the cookies it issued have expired.
+	CookieKeys [][]byte
+
+	// If enabled, signed cookies are encrypted with AES-GCM instead of only
+	// being signed with HMAC-SHA256, so clients cannot read their values.
+	EncryptCookies bool
+
+	// Maximum number of bytes of a multipart form that are kept in memory.
+	// Larger files are stored in temporary files. Defaults to 32 MB.
+	MaxMultipartMemory int64
+
+	// Maximum size in bytes of a URL-encoded form or multipart request
+	// body. Larger uploads are rejected with ErrRequestEntityTooLarge, even
+	// if the body was already read by Context.Body. Zero means no limit.
+	MaxUploadSize int64
+
+	// Maximum size in bytes of a request body read by Context.Body,
+	// Context.BodyReader and the Bind methods. Larger bodies are rejected
+	// with ErrRequestEntityTooLarge. Zero means DefaultMaxBodySize, a
+	// negative value disables the limit.
+	MaxBodySize int64
+
+	// Timeouts and limits of the http.Server started by the Run methods. See http.Server
+	// for their meaning. New sets ReadHeaderTimeout, IdleTimeout and
+	// MaxHeaderBytes to safe defaults. ReadTimeout and WriteTimeout are left
+	// at zero, as they would cut off long-lived streams such as SSE.
+	ReadTimeout       time.Duration
+	ReadHeaderTimeout time.Duration
+	WriteTimeout      time.Duration
+	IdleTimeout       time.Duration
+	MaxHeaderBytes    int
+
+	// If enabled, the server accepts cleartext HTTP/2 (h2c) connections with
+	// prior knowledge, next to HTTP/1. This is meant for servers behind a
+	// proxy or service mesh that terminates TLS.
+	EnableH2C bool
+
+	// If set, RunTLS also listens on this address and redirects plain HTTP
+	// requests to HTTPS, e.g. ":80".
+	RedirectHTTPAddr string
+
+	// If enabled, Run shuts the server down gracefully on SIGINT and SIGTERM.
+	HandleSignals bool
+
+	// Maximum time to wait for active requests to complete when the server is
+	// shut down on a signal. Defaults to DefaultShutdownTimeout.
+	ShutdownTimeout time.Duration
+
+	lifecycle lifecycle // Hooks and state of the running server
+
 	pool *sync.Pool // Pool for Context objects
 }
 
@@ -111,6 +182,10 @@ func New() *Nexora {
 		HandleMethodNotAllowed: true,
 		HandleOPTIONS:          true,
 		namedRoutes:            make(map[string]*Route),
+		MaxMultipartMemory:     defaultMaxMultipartMemory,
+		ReadHeaderTimeout:      DefaultReadHeaderTimeout,
+		IdleTimeout:            DefaultIdleTimeout,
+		MaxHeaderBytes:         http.DefaultMaxHeaderBytes,
 	}
 	nexora.RouteGroup = *newRouteGroup(nexora, "", make([]Handler, 0))
 	nexora.pool = &sync.Pool{
@@ -174,7 +249,60 @@ func (n *Nexora) recv(c *Context) {
 // It panics if the method is empty or no handlers are provided.
 // The path must start with a '/' character.
 // If the path is invalid, it panics with an error message.
+// It panics if a route registered before matches the same requests, see Validate.
 func (n *Nexora) Handle(method, path string, handlers ...Handler) {
+	n.register(&Route{
+		group:    &n.RouteGroup,
+		method:   method,
+		path:     path,
+		template: buildURLTemplate(path),
+		handlers: handlers,
+	})
+}
+
+// register adds the route to the routing trees. Routes with the same host,
+// method and path share a route set, which selects one of them by their
+// matchers.
+func (n *Nexora) register(r *Route) {
+	if len(r.handlers) == 0 {
+		panic("nexora: at least one handler must be provided")
+	}
+
+	r.source = callerSource()
+
+	key := r.key()
+	set, ok := n.routeSets[key]
+	if !ok {
+		set = newRouteSet()
+		set.paths = n.handle(r, r.handlers)
+		if n.routeSets == nil {
+			n.routeSets = make(map[string]*routeSet)
+		}
+		n.routeSets[key] = set
+	} else {
+		// A route without matchers matches all the requests for its path,
+		// so the routes registered after it would never be matched
+		for _, prev := range set.routes {
+			if len(prev.matchers) == 0 {
+				panic(newRadixError(errSetHandler, r.host()+r.Path()))
+			}
+		}
+		set.dispatch()
+	}
+
+	if params := len(routeParams(r.Path())) + r.group.host.paramCount(); params > n.maxParams {
+		n.maxParams = params
+	}
+	set.routes = append(set.routes, r)
+	n.routes = append(n.routes, r)
+}
+
+// handle adds the handlers for the method and path of the route to the
+// routing trees, or to the trees of its host, and returns the paths under
+// which they were added.
+func (n *Nexora) handle(r *Route, handlers []Handler) []treePath {
+	h, method, path := r.group.host, r.method, r.Path()
+
 	switch {
 	case len(method) == 0:
 		panic("nexora: method must not be empty")
@@ -182,40 +310,49 @@ func (n *Nexora) Handle(method, path string, handlers ...Handler) {
 		panic("nexora: at least one handler must be provided")
 	default:
 		validatePath(path)
+		n.checkConstraints(path)
 	}
 
-	path = parseConstraintsRoute(path)
-
-	n.registeredPaths[method] = append(n.registeredPaths[method], path)
-
 	methodIndex := n.methodIndexOf(method)
 	if methodIndex == -1 {
-		tree := newTree()
-		tree.Mutable = n.treeMutable
-
-		n.trees = append(n.trees, tree)
-		methodIndex = len(n.trees) - 1
-		n.globalAllowed = n.allowed("*", "")
+		methodIndex = len(n.trees)
+		n.customMethodsIndex[method] = methodIndex
+		n.trees = append(n.trees, nil)
 	}
 
-	tree := n.trees[methodIndex]
-	if tree == nil {
-		tree = newTree()
-		tree.Mutable = n.treeMutable
-		n.trees[methodIndex] = tree
-		n.globalAllowed = n.allowed("*", "")
+	var tree *tree
+	if h != nil {
+		tree = h.treeFor(methodIndex, n.treeMutable, n.resolveConstraint)
+	} else {
+		n.registeredPaths[method] = append(n.registeredPaths[method], path)
+
+		tree = n.trees[methodIndex]
+		if tree == nil {
+			tree = newTree()
+			tree.Mutable = n.treeMutable
+			tree.constraints = n.resolveConstraint
+			n.trees[methodIndex] = tree
+			n.globalAllowed = n.allowed("*", "")
+		}
 	}
 
 	optionalPaths := getOptionalPaths(path)
 	if len(optionalPaths) == 0 {
 		// No optional paths, add the path as is
-		tree.Add(path, handlers)
-	} else {
-		// Add all optional paths
-		for _, p := range optionalPaths {
-			tree.Add(p, handlers)
+		optionalPaths = []string{path}
+	}
+
+	var paths []treePath
+	for _, p := range optionalPaths {
+		if n.addPath(tree, r, p, handlers) {
+			paths = append(paths, treePath{tree, p})
 		}
 	}
+	if len(paths) == 0 {
+		// The route would never be matched
+		panic(r.conflicts[len(r.conflicts)-1])
+	}
+	return paths
 }
 
 // allowed returns a comma-separated string of allowed HTTP methods for the given path.
@@ -354,6 +491,10 @@ func (n *Nexora) tryRedirect(w http.ResponseWriter, r *http.Request, tree *tree,
 }
 
 func (n *Nexora) handleError(c *Context, err error) {
+	// Drop the partial output of a buffered response, so the error
+	// response is not appended to it.
+	c.writer.Reset()
+
 	if n.ErrorHandler != nil {
 		if handlerErr := n.ErrorHandler(c, err); handlerErr != nil {
 			// NOTE: Replace it later with nexora custom logger
@@ -363,7 +504,20 @@ func (n *Nexora) handleError(c *Context, err error) {
 		return
 	}
 
-	if httpErr, ok := err.(*HTTPError); ok {
+	// Nothing can be sent once the connection has been taken over, e.g. by
+	// a WebSocket upgrade.
+	if c.writer.Hijacked() {
+		log.Printf("Unhandled error after hijacking the connection: %v", err)
+		return
+	}
+
+	var validationErrs ValidationErrors
+	if errors.As(err, &validationErrs) {
+		c.SetHeader(HeaderXContentTypeOptions, "nosniff")
+		if err := c.JSON(StatusUnprocessableEntity, map[string]any{"errors": validationErrs}); err != nil {
+			log.Printf("Failed to write validation errors: %v", err)
+		}
+	} else if httpErr, ok := err.(*HTTPError); ok {
 		http.Error(c.ResponseWriter(), httpErr.Message, httpErr.StatusCode)
 	} else {
 		// NOTE: Replace it later with nexora custom logger
@@ -378,19 +532,27 @@ func (n *Nexora) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	c := n.pool.Get().(*Context)
 	defer func() {
 		n.recv(c)
+		c.release()
+		c.writer.finish()
 		n.pool.Put(c)
 	}()
 
 	c.init(r, w)
+	// Write through the wrapper, so buffering and hooks apply to the
+	// responses written by the router itself.
+	w = c.response
 
 	path := r.URL.Path
 	method := r.Method
 	methodIndex := n.methodIndexOf(method)
 
+	if len(n.hosts) > 0 && n.serveHost(c, methodIndex, path) {
+		return
+	}
+
 	if methodIndex > -1 {
 		if tree := n.trees[methodIndex]; tree != nil {
-			if handlers, params, tsr := tree.Get(path); handlers != nil {
-				c.params = params
+			if handlers, tsr := tree.lookup(path, &c.params); handlers != nil {
 				c.handlers = handlers
 				if err := c.Next(); err != nil {
 					n.handleError(c, err)
@@ -405,8 +567,7 @@ func (n *Nexora) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if tree := n.trees[n.methodIndexOf(MethodWild)]; tree != nil {
-		if handler, params, tsr := tree.Get(path); handler != nil {
-			c.params = params
+		if handler, tsr := tree.lookup(path, &c.params); handler != nil {
 			c.handlers = handler
 			if err := c.Next(); err != nil {
 				n.handleError(c, err)
@@ -459,18 +620,3 @@ func (n *Nexora) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusNotFound)
 	}
 }
-
-// Run starts the HTTP server on the specified address.
-// It uses the ServeHTTP method to handle incoming requests.
-// The address should be in the format "host:port", e.g., ":8080" or "localhost:8080".
-// It returns an error if the server fails to start.
-// Example usage: nexora.Run(":8080")
-// If the address is empty, it defaults to ":8080".
-func (n *Nexora) Run(addr string) error {
-	server := &http.Server{
-		Addr:    addr,
-		Handler: n,
-	}
-
-	return server.ListenAndServe()
-}
@@ -38,6 +38,20 @@ func TestHandleAndServeHTTP(t *testing.T) {
 	}
 }
 
+func TestCustomMethod(t *testing.T) {
+	router := New()
+	router.Handle("PURGE", "/cache/a", dummyHandler("a"))
+	router.Handle("PURGE", "/cache/b", dummyHandler("b"))
+
+	for _, path := range []string{"/cache/a", "/cache/b"} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("PURGE", path, nil))
+		if w.Code != http.StatusOK || w.Body.String() != path[len(path)-1:] {
+			t.Errorf("PURGE %s = %d %q", path, w.Code, w.Body.String())
+		}
+	}
+}
+
 func TestNotFound(t *testing.T) {
 	router := New()
 
@@ -154,3 +168,22 @@ func TestOptionalParams(t *testing.T) {
 		t.Fatalf("Expected 'Hello, Guest', got '%s'", string(bodyWithout))
 	}
 }
+
+func BenchmarkNexora_ServeHTTPParams(b *testing.B) {
+	for _, bm := range paramBenchmarks {
+		app := New()
+		app.Get(bm.route, func(c *Context) error {
+			c.Param("id")
+			return nil
+		})
+		req := httptest.NewRequest("GET", bm.path, nil)
+		rec := httptest.NewRecorder()
+
+		b.Run(bm.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				app.ServeHTTP(rec, req)
+			}
+		})
+	}
+}
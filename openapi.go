@@ -0,0 +1,812 @@
+package nexora
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"log"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// OpenAPIVersion is the version of the OpenAPI Specification implemented by
+// documents generated by GenerateOpenAPI.
+const OpenAPIVersion = "3.1.0"
+
+// UIs that can be served next to the OpenAPI document by ServeOpenAPI.
+const (
+	OpenAPIUISwagger = "swagger"
+	OpenAPIUIRedoc   = "redoc"
+)
+
+// Versions of the UIs loaded by default. They are pinned, so that the page
+// doesn't change when a new version is published.
+const (
+	OpenAPIUISwaggerVersion = "5.17.14"
+	OpenAPIUIRedocVersion   = "2.1.5"
+)
+
+// OpenAPIUIAsset is a script or stylesheet loaded by the UI page.
+type OpenAPIUIAsset struct {
+	URL string
+
+	// Subresource Integrity hash of the file, e.g. "sha384-...". The browser
+	// refuses to load the file if its content doesn't match.
+	Integrity string
+}
+
+// OpenAPIConfig configures the document generated by GenerateOpenAPI and
+// served by ServeOpenAPI.
+type OpenAPIConfig struct {
+	Title       string   // Title of the API. Defaults to "API".
+	Version     string   // Version of the API. Defaults to "1.0.0".
+	Description string   // Description of the API, CommonMark syntax may be used.
+	Servers     []string // URLs of the servers hosting the API.
+
+	// Path under which ServeOpenAPI serves the document. Defaults to "/openapi.json".
+	Path string
+
+	// UI served by ServeOpenAPI, OpenAPIUISwagger or OpenAPIUIRedoc.
+	// No UI is served if it is empty.
+	UI string
+
+	// Path under which ServeOpenAPI serves the UI. Defaults to "/docs".
+	UIPath string
+
+	// Script and stylesheet of the UI. They default to the pinned versions
+	// on cdn.jsdelivr.net, without integrity hash. Set them to load copies
+	// served by the application, or to add integrity hashes.
+	// Redoc has no stylesheet.
+	UIScript     OpenAPIUIAsset
+	UIStylesheet OpenAPIUIAsset
+}
+
+// RouteDoc documents a route in the generated OpenAPI document.
+// It is attached to a route with Route.Doc.
+//
+// The Query, Request and Responses values are only used for their types.
+// Struct fields are described using their `json` tags, or `query` tags for
+// Query, and the `validate` rules of the fields are turned into schema
+// constraints such as required, minimum or maxLength.
+type RouteDoc struct {
+	Summary     string
+	Description string
+	OperationID string   // Defaults to the route name
+	Tags        []string // Names used to group operations
+	Deprecated  bool
+	Hidden      bool // Excludes the route from the document
+
+	// A struct describing the query parameters, as bound by BindQuery.
+	Query any
+
+	// A value describing the JSON request body.
+	Request any
+
+	// Values describing the JSON response bodies by status code. A nil value
+	// describes a response without body. Defaults to a 200 response without body.
+	Responses map[int]any
+}
+
+// Doc attaches documentation to the route for the generated OpenAPI document.
+//
+// Example:
+//
+//	app.Post("/users", createUser).Doc(nexora.RouteDoc{
+//		Summary:   "Create a user",
+//		Request:   CreateUser{},
+//		Responses: map[int]any{201: User{}, 422: nexora.ValidationErrors{}},
+//	})
+func (r *Route) Doc(doc RouteDoc) *Route {
+	return r.Tag(doc)
+}
+
+// doc returns the last RouteDoc attached to the route.
+func (r *Route) doc() RouteDoc {
+	for i := len(r.tags) - 1; i >= 0; i-- {
+		if doc, ok := r.tags[i].(RouteDoc); ok {
+			return doc
+		}
+	}
+	return RouteDoc{}
+}
+
+// OpenAPIDocument is the root object of an OpenAPI document.
+type OpenAPIDocument struct {
+	OpenAPI    string                                  `json:"openapi"`
+	Info       OpenAPIInfo                             `json:"info"`
+	Servers    []OpenAPIServer                         `json:"servers,omitempty"`
+	Paths      map[string]map[string]*OpenAPIOperation `json:"paths"` // Operations by path template and lowercase method
+	Components *OpenAPIComponents                      `json:"components,omitempty"`
+}
+
+// OpenAPIInfo holds the metadata of the API.
+type OpenAPIInfo struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+// OpenAPIServer describes a server hosting the API.
+type OpenAPIServer struct {
+	URL string `json:"url"`
+}
+
+// OpenAPIOperation describes a single operation on a path.
+type OpenAPIOperation struct {
+	OperationID string                      `json:"operationId,omitempty"`
+	Summary     string                      `json:"summary,omitempty"`
+	Description string                      `json:"description,omitempty"`
+	Tags        []string                    `json:"tags,omitempty"`
+	Deprecated  bool                        `json:"deprecated,omitempty"`
+	Parameters  []*OpenAPIParameter         `json:"parameters,omitempty"`
+	RequestBody *OpenAPIRequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*OpenAPIResponse `json:"responses"`
+}
+
+// OpenAPIParameter describes a path or query parameter.
+type OpenAPIParameter struct {
+	Name     string         `json:"name"`
+	In       string         `json:"in"`
+	Required bool           `json:"required,omitempty"`
+	Schema   *OpenAPISchema `json:"schema"`
+}
+
+// OpenAPIRequestBody describes a request body.
+type OpenAPIRequestBody struct {
+	Required bool                        `json:"required,omitempty"`
+	Content  map[string]OpenAPIMediaType `json:"content"`
+}
+
+// OpenAPIResponse describes a response.
+type OpenAPIResponse struct {
+	Description string                      `json:"description"`
+	Content     map[string]OpenAPIMediaType `json:"content,omitempty"`
+}
+
+// OpenAPIMediaType describes the body of a request or response for a media type.
+type OpenAPIMediaType struct {
+	Schema *OpenAPISchema `json:"schema"`
+}
+
+// OpenAPIComponents holds the schemas referenced by the document.
+type OpenAPIComponents struct {
+	Schemas map[string]*OpenAPISchema `json:"schemas"`
+}
+
+// OpenAPISchema is a JSON Schema describing a value.
+type OpenAPISchema struct {
+	Ref                  string                    `json:"$ref,omitempty"`
+	Type                 string                    `json:"type,omitempty"`
+	Format               string                    `json:"format,omitempty"`
+	Pattern              string                    `json:"pattern,omitempty"`
+	Enum                 []any                     `json:"enum,omitempty"`
+	Minimum              *float64                  `json:"minimum,omitempty"`
+	Maximum              *float64                  `json:"maximum,omitempty"`
+	MinLength            *int                      `json:"minLength,omitempty"`
+	MaxLength            *int                      `json:"maxLength,omitempty"`
+	MinItems             *int                      `json:"minItems,omitempty"`
+	MaxItems             *int                      `json:"maxItems,omitempty"`
+	Items                *OpenAPISchema            `json:"items,omitempty"`
+	Properties           map[string]*OpenAPISchema `json:"properties,omitempty"`
+	Required             []string                  `json:"required,omitempty"`
+	AdditionalProperties *OpenAPISchema            `json:"additionalProperties,omitempty"`
+}
+
+// openAPIMethods are the methods that can be described in an OpenAPI
+// document. Routes with other methods are left out.
+var openAPIMethods = map[string]bool{
+	MethodGet:     true,
+	MethodHead:    true,
+	MethodPost:    true,
+	MethodPut:     true,
+	MethodPatch:   true,
+	MethodDelete:  true,
+	MethodOptions: true,
+	MethodTrace:   true,
+}
+
+// GenerateOpenAPI generates an OpenAPI document from the registered routes.
+//
+// Typed path parameters are described with matching schemas, e.g. {id:int}
+// as an integer and {age:range(18,120)} as an integer between 18 and 120.
+// A route with optional parameters is described under each of its paths;
+// only the path with all parameters gets the operation ID.
+// Further details are added with Route.Doc.
+//
+// An OpenAPI document describes a single operation per path and method, so
+// routes which only differ by their matchers or the constraints of their
+// parameters can't all be described. The first one registered is kept and
+// an error is returned for each of the others, joined with errors.Join,
+// together with the document. Use RouteDoc.Hidden to leave them out.
+func (n *Nexora) GenerateOpenAPI(config OpenAPIConfig) (*OpenAPIDocument, error) {
+	doc := &OpenAPIDocument{
+		OpenAPI: OpenAPIVersion,
+		Info: OpenAPIInfo{
+			Title:       config.Title,
+			Version:     config.Version,
+			Description: config.Description,
+		},
+		Paths: make(map[string]map[string]*OpenAPIOperation),
+	}
+	if doc.Info.Title == "" {
+		doc.Info.Title = "API"
+	}
+	if doc.Info.Version == "" {
+		doc.Info.Version = "1.0.0"
+	}
+	for _, url := range config.Servers {
+		doc.Servers = append(doc.Servers, OpenAPIServer{URL: url})
+	}
+
+	schemas := newSchemaGenerator()
+	schemas.constraints = n.constraints
+
+	var errs []error
+	described := make(map[string]*Route)
+	for _, r := range n.routes {
+		routeDoc := r.doc()
+		if routeDoc.Hidden || !openAPIMethods[r.method] {
+			continue
+		}
+
+		paths := getOptionalPaths(r.Path())
+		if len(paths) == 0 {
+			paths = []string{r.Path()}
+		}
+
+		for i, path := range paths {
+			template := buildURLTemplate(path)
+			key := r.method + " " + template
+			if existing, ok := described[key]; ok {
+				errs = append(errs, fmt.Errorf("nexora: route %s %s registered at %s is described as %s, like %s %s registered at %s",
+					r.method, r.Path(), r.source, template, existing.method, existing.Path(), existing.source))
+				continue
+			}
+			described[key] = r
+
+			op := schemas.operation(routeDoc, path)
+			if i == len(paths)-1 {
+				op.OperationID = routeDoc.OperationID
+				if op.OperationID == "" {
+					op.OperationID = r.name
+				}
+			}
+
+			if doc.Paths[template] == nil {
+				doc.Paths[template] = make(map[string]*OpenAPIOperation)
+			}
+			doc.Paths[template][strings.ToLower(r.method)] = op
+		}
+	}
+
+	if len(schemas.schemas) > 0 {
+		doc.Components = &OpenAPIComponents{Schemas: schemas.schemas}
+	}
+
+	return doc, errors.Join(errs...)
+}
+
+// ServeOpenAPI serves the OpenAPI document generated by GenerateOpenAPI as
+// JSON, and optionally a UI to browse it. The document is generated on the
+// first request, so routes registered after ServeOpenAPI are included.
+// The returned route is the one serving the document. If some routes can't
+// be described, the error of GenerateOpenAPI is logged when the document is
+// generated, and the document is served without them.
+//
+// Example:
+//
+//	app.ServeOpenAPI(nexora.OpenAPIConfig{
+//		Title: "Pet Store",
+//		UI:    nexora.OpenAPIUISwagger,
+//	})
+func (g *RouteGroup) ServeOpenAPI(config OpenAPIConfig) *Route {
+	if config.Path == "" {
+		config.Path = "/openapi.json"
+	}
+	if config.UIPath == "" {
+		config.UIPath = "/docs"
+	}
+
+	var (
+		once sync.Once
+		spec []byte
+		err  error
+	)
+	route := g.Get(config.Path, func(c *Context) error {
+		once.Do(func() {
+			doc, genErr := g.nexora.GenerateOpenAPI(config)
+			if genErr != nil {
+				// The document is still valid, without the routes which
+				// couldn't be described
+				log.Printf("[nexora] %v", genErr)
+			}
+			spec, err = json.Marshal(doc)
+		})
+		if err != nil {
+			return err
+		}
+		return c.Blob(StatusOK, MIMEApplicationJSONCharsetUTF8, spec)
+	}).Doc(RouteDoc{Hidden: true})
+
+	if config.UI != "" {
+		page := openAPIUIPage(config, g.prefix+config.Path)
+		g.Get(config.UIPath, func(c *Context) error {
+			return c.HTML(StatusOK, page)
+		}).Doc(RouteDoc{Hidden: true})
+	}
+
+	return route
+}
+
+// openAPIUIPage returns the HTML page of the UI loading the document at specURL.
+func openAPIUIPage(config OpenAPIConfig, specURL string) string {
+	title := config.Title
+	if title == "" {
+		title = "API"
+	}
+
+	script, stylesheet := config.UIScript, config.UIStylesheet
+	var body string
+	switch config.UI {
+	case OpenAPIUISwagger:
+		const dist = "https://cdn.jsdelivr.net/npm/swagger-ui-dist@" + OpenAPIUISwaggerVersion
+		if script.URL == "" {
+			script.URL = dist + "/swagger-ui-bundle.js"
+		}
+		if stylesheet.URL == "" {
+			stylesheet.URL = dist + "/swagger-ui.css"
+		}
+
+		url, _ := json.Marshal(specURL)
+		body = `<link rel="stylesheet" href="` + html.EscapeString(stylesheet.URL) + `"` + integrityAttrs(stylesheet) + `>
+<div id="swagger-ui"></div>
+<script src="` + html.EscapeString(script.URL) + `"` + integrityAttrs(script) + `></script>
+<script>window.ui = SwaggerUIBundle({url: ` + string(url) + `, dom_id: "#swagger-ui"});</script>`
+	case OpenAPIUIRedoc:
+		if script.URL == "" {
+			script.URL = "https://cdn.jsdelivr.net/npm/redoc@" + OpenAPIUIRedocVersion + "/bundles/redoc.standalone.js"
+		}
+
+		body = `<redoc spec-url="` + html.EscapeString(specURL) + `"></redoc>
+<script src="` + html.EscapeString(script.URL) + `"` + integrityAttrs(script) + `></script>`
+	default:
+		panicf("nexora: unknown OpenAPI UI %q", config.UI)
+	}
+
+	return `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>` + html.EscapeString(title) + `</title>
+</head>
+<body>
+` + body + `
+</body>
+</html>
+`
+}
+
+// integrityAttrs returns the attributes checking the integrity of the asset,
+// if it has a hash.
+func integrityAttrs(asset OpenAPIUIAsset) string {
+	if asset.Integrity == "" {
+		return ""
+	}
+	return ` integrity="` + html.EscapeString(asset.Integrity) + `" crossorigin="anonymous"`
+}
+
+// schemaGenerator generates schemas from Go types. Named struct types are
+// added to the components of the document and referenced.
+type schemaGenerator struct {
+	schemas     map[string]*OpenAPISchema
+	names       map[reflect.Type]string
+	constraints map[string]Constraint // Constraints registered with RegisterConstraint
+}
+
+func newSchemaGenerator() *schemaGenerator {
+	return &schemaGenerator{
+		schemas: make(map[string]*OpenAPISchema),
+		names:   make(map[reflect.Type]string),
+	}
+}
+
+// operation returns the operation described by doc on the given path.
+func (g *schemaGenerator) operation(doc RouteDoc, path string) *OpenAPIOperation {
+	op := &OpenAPIOperation{
+		Summary:     doc.Summary,
+		Description: doc.Description,
+		Tags:        doc.Tags,
+		Deprecated:  doc.Deprecated,
+		Responses:   make(map[string]*OpenAPIResponse),
+	}
+
+	for _, param := range routeParams(path) {
+		op.Parameters = append(op.Parameters, &OpenAPIParameter{
+			Name:     param.Name,
+			In:       "path",
+			Required: true,
+			Schema:   g.paramSchema(param),
+		})
+	}
+
+	if doc.Query != nil {
+		op.Parameters = append(op.Parameters, g.queryParams(reflect.TypeOf(doc.Query))...)
+	}
+
+	if doc.Request != nil {
+		op.RequestBody = &OpenAPIRequestBody{
+			Required: true,
+			Content:  g.content(doc.Request),
+		}
+	}
+
+	for code, body := range doc.Responses {
+		description := http.StatusText(code)
+		if description == "" {
+			description = "Response"
+		}
+		response := &OpenAPIResponse{Description: description}
+		if body != nil {
+			response.Content = g.content(body)
+		}
+		op.Responses[strconv.Itoa(code)] = response
+	}
+	if len(op.Responses) == 0 {
+		op.Responses["200"] = &OpenAPIResponse{Description: http.StatusText(StatusOK)}
+	}
+
+	return op
+}
+
+// content returns the JSON content describing v.
+func (g *schemaGenerator) content(v any) map[string]OpenAPIMediaType {
+	return map[string]OpenAPIMediaType{
+		MIMEApplicationJSON: {Schema: g.schema(reflect.TypeOf(v))},
+	}
+}
+
+// queryParams returns the query parameters described by the `query` tags of
+// the struct type t, walking embedded and nested structs like BindQuery.
+func (g *schemaGenerator) queryParams(t reflect.Type) []*OpenAPIParameter {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		panicf("nexora: RouteDoc.Query must be a struct, got %s", t)
+	}
+
+	var params []*OpenAPIParameter
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() && !sf.Anonymous {
+			continue
+		}
+
+		name, _, _ := strings.Cut(sf.Tag.Get("query"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			if sf.Type.Kind() == reflect.Struct && !reflect.PointerTo(sf.Type).Implements(textUnmarshalerType) {
+				params = append(params, g.queryParams(sf.Type)...)
+			}
+			continue
+		}
+
+		schema := g.schema(sf.Type)
+		required := applyValidateRules(schema, sf)
+		params = append(params, &OpenAPIParameter{
+			Name:     name,
+			In:       "query",
+			Required: required,
+			Schema:   schema,
+		})
+	}
+	return params
+}
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+// schema returns the schema of values of type t, as encoded by encoding/json.
+func (g *schemaGenerator) schema(t reflect.Type) *OpenAPISchema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == timeType:
+		return &OpenAPISchema{Type: "string", Format: "date-time"}
+	case t.Implements(jsonMarshalerType) || reflect.PointerTo(t).Implements(jsonMarshalerType):
+		return &OpenAPISchema{}
+	case t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType):
+		return &OpenAPISchema{Type: "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &OpenAPISchema{Type: "boolean"}
+	case reflect.Int8, reflect.Int16, reflect.Int32:
+		return &OpenAPISchema{Type: "integer", Format: "int32"}
+	case reflect.Int, reflect.Int64:
+		return &OpenAPISchema{Type: "integer", Format: "int64"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return &OpenAPISchema{Type: "integer", Minimum: ptr(0.0)}
+	case reflect.Float32:
+		return &OpenAPISchema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &OpenAPISchema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &OpenAPISchema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 {
+			return &OpenAPISchema{Type: "string", Format: "byte"}
+		}
+		return &OpenAPISchema{Type: "array", Items: g.schema(t.Elem())}
+	case reflect.Map:
+		return &OpenAPISchema{Type: "object", AdditionalProperties: g.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.object(t)
+		}
+		return &OpenAPISchema{Ref: "#/components/schemas/" + g.component(t)}
+	}
+
+	// Interfaces and other types accept any value
+	return &OpenAPISchema{}
+}
+
+// invalidComponentChars matches the characters not allowed in component names.
+var invalidComponentChars = regexp.MustCompile(`[^A-Za-z0-9._-]`)
+
+// component adds the schema of the named struct type t to the components, and
+// returns its name.
+func (g *schemaGenerator) component(t reflect.Type) string {
+	if name, ok := g.names[t]; ok {
+		return name
+	}
+
+	base := invalidComponentChars.ReplaceAllString(t.Name(), "_")
+	name := base
+	for i := 2; g.schemas[name] != nil; i++ {
+		name = fmt.Sprintf("%s%d", base, i)
+	}
+
+	// The name is registered first, so recursive types reference it.
+	g.names[t] = name
+	g.schemas[name] = &OpenAPISchema{}
+	*g.schemas[name] = *g.object(t)
+
+	return name
+}
+
+// object returns the object schema of the struct type t.
+func (g *schemaGenerator) object(t reflect.Type) *OpenAPISchema {
+	schema := &OpenAPISchema{Type: "object", Properties: make(map[string]*OpenAPISchema)}
+	g.addFields(schema, t)
+	return schema
+}
+
+// addFields adds the fields of the struct type t to the object schema.
+// Fields of embedded structs are added as if they were declared in t.
+func (g *schemaGenerator) addFields(schema *OpenAPISchema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+
+		name, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+
+		if sf.Anonymous && name == "" {
+			ft := sf.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				g.addFields(schema, ft)
+				continue
+			}
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+
+		property := g.schema(sf.Type)
+		if applyValidateRules(property, sf) {
+			schema.Required = append(schema.Required, name)
+		}
+		schema.Properties[name] = property
+	}
+}
+
+// applyValidateRules adds the constraints of the `validate` rules of the field
+// to its schema. It reports whether the field is required.
+func applyValidateRules(schema *OpenAPISchema, sf reflect.StructField) (required bool) {
+	tag := sf.Tag.Get("validate")
+	if tag == "" || tag == "-" {
+		return false
+	}
+
+	for _, rule := range splitRules(tag) {
+		rule, param, _ := strings.Cut(rule, "=")
+		if schema.Ref != "" && rule != "required" {
+			// Constraints can't be added next to a reference
+			continue
+		}
+
+		switch rule {
+		case "omitempty":
+		case "required":
+			required = true
+		case "min", "max", "len":
+			limit, err := strconv.ParseFloat(param, 64)
+			if err != nil {
+				continue
+			}
+			applyBound(schema, rule, limit)
+		case "oneof":
+			for _, option := range strings.Fields(param) {
+				var value any = option
+				if schema.Type == "integer" || schema.Type == "number" {
+					if f, err := strconv.ParseFloat(option, 64); err == nil {
+						value = f
+					}
+				}
+				schema.Enum = append(schema.Enum, value)
+			}
+		case "regex":
+			schema.Pattern = param
+		default:
+			if _, ok := resolveConstraint(rule); ok && schema.Type == "string" {
+				format := constraintSchema(rule)
+				schema.Format, schema.Pattern = format.Format, format.Pattern
+			}
+		}
+	}
+
+	return required
+}
+
+// applyBound adds a min, max or len rule to the schema.
+func applyBound(schema *OpenAPISchema, rule string, limit float64) {
+	n := int(limit)
+
+	switch schema.Type {
+	case "integer", "number":
+		switch rule {
+		case "min":
+			schema.Minimum = &limit
+		case "max":
+			schema.Maximum = &limit
+		}
+	case "string":
+		switch rule {
+		case "min":
+			schema.MinLength = &n
+		case "max":
+			schema.MaxLength = &n
+		case "len":
+			schema.MinLength, schema.MaxLength = &n, &n
+		}
+	case "array":
+		switch rule {
+		case "min":
+			schema.MinItems = &n
+		case "max":
+			schema.MaxItems = &n
+		case "len":
+			schema.MinItems, schema.MaxItems = &n, &n
+		}
+	}
+}
+
+// paramSchema returns the schema of a path parameter, which is a string
+// matching the pattern of the constraint if it is a registered one.
+func (g *schemaGenerator) paramSchema(param ParamInfo) *OpenAPISchema {
+	name, _ := splitConstraint(param.Constraint)
+	c, ok := g.constraints[name]
+	if !ok {
+		return paramSchema(param)
+	}
+
+	schema := &OpenAPISchema{Type: "string"}
+	if c.Pattern != "" {
+		schema.Pattern = "^(?:" + c.Pattern + ")$"
+	}
+	return schema
+}
+
+// paramSchema returns the schema of a path parameter.
+func paramSchema(param ParamInfo) *OpenAPISchema {
+	typ, args := splitConstraint(param.Constraint)
+
+	switch typ {
+	case "", "string", "path":
+		return &OpenAPISchema{Type: "string"}
+	case "int", "int64":
+		return &OpenAPISchema{Type: "integer", Format: "int64"}
+	case "int32":
+		return &OpenAPISchema{Type: "integer", Format: "int32"}
+	case "int8":
+		return &OpenAPISchema{Type: "integer", Format: "int32", Minimum: ptr(-128.0), Maximum: ptr(127.0)}
+	case "int16":
+		return &OpenAPISchema{Type: "integer", Format: "int32", Minimum: ptr(-32768.0), Maximum: ptr(32767.0)}
+	case "uint", "uint64":
+		return &OpenAPISchema{Type: "integer", Minimum: ptr(0.0)}
+	case "uint8":
+		return &OpenAPISchema{Type: "integer", Minimum: ptr(0.0), Maximum: ptr(255.0)}
+	case "uint16":
+		return &OpenAPISchema{Type: "integer", Minimum: ptr(0.0), Maximum: ptr(65535.0)}
+	case "uint32":
+		return &OpenAPISchema{Type: "integer", Minimum: ptr(0.0), Maximum: ptr(4294967295.0)}
+	case "float32":
+		return &OpenAPISchema{Type: "number", Format: "float"}
+	case "float64":
+		return &OpenAPISchema{Type: "number", Format: "double"}
+	case "bool":
+		return &OpenAPISchema{Type: "boolean"}
+	case "range":
+		lo, hi, _ := strings.Cut(args, ",")
+		return &OpenAPISchema{Type: "integer", Minimum: ptr(float64(constraintArg(param.Constraint, lo))), Maximum: ptr(float64(constraintArg(param.Constraint, hi)))}
+	case "min":
+		return &OpenAPISchema{Type: "integer", Minimum: ptr(float64(constraintArg(param.Constraint, args)))}
+	case "max":
+		return &OpenAPISchema{Type: "integer", Minimum: ptr(0.0), Maximum: ptr(float64(constraintArg(param.Constraint, args)))}
+	case "len":
+		n := int(constraintArg(param.Constraint, args))
+		return &OpenAPISchema{Type: "string", MinLength: &n, MaxLength: &n}
+	case "minlen":
+		return &OpenAPISchema{Type: "string", MinLength: ptr(int(constraintArg(param.Constraint, args)))}
+	case "maxlen":
+		return &OpenAPISchema{Type: "string", MaxLength: ptr(int(constraintArg(param.Constraint, args)))}
+	}
+
+	if _, ok := constraintsType[typ]; ok && args == "" {
+		return constraintSchema(typ)
+	}
+
+	// Anything else is a regular expression
+	return &OpenAPISchema{Type: "string", Pattern: "^(?:" + param.Constraint + ")$"}
+}
+
+// constraintFormats maps the string constraint types to JSON Schema formats.
+var constraintFormats = map[string]string{
+	"uuid":     "uuid",
+	"email":    "email",
+	"ip":       "ipv4",
+	"ipv6":     "ipv6",
+	"hostname": "hostname",
+	"date":     "date",
+}
+
+// constraintSchema returns the string schema of a constraint type, using a
+// format if JSON Schema defines one, or else the pattern of the constraint.
+func constraintSchema(typ string) *OpenAPISchema {
+	if format, ok := constraintFormats[typ]; ok {
+		return &OpenAPISchema{Type: "string", Format: format}
+	}
+	if c, ok := resolveConstraint(typ); ok && c.pattern != "" {
+		return &OpenAPISchema{Type: "string", Pattern: "^(?:" + c.pattern + ")$"}
+	}
+	return &OpenAPISchema{Type: "string"}
+}
+
+// ptr returns a pointer to v.
+func ptr[T any](v T) *T {
+	return &v
+}
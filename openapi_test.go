@@ -0,0 +1,217 @@
+package nexora
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type openAPITestUser struct {
+	ID        int64              `json:"id"`
+	Email     string             `json:"email" validate:"required,email"`
+	Name      string             `json:"name" validate:"required,min=2,max=64"`
+	Role      string             `json:"role,omitempty" validate:"oneof=admin user"`
+	Tags      []string           `json:"tags" validate:"max=5"`
+	Friends   []*openAPITestUser `json:"friends,omitempty"`
+	CreatedAt time.Time          `json:"created_at"`
+	password  string
+}
+
+type openAPITestPage struct {
+	Page  int    `query:"page" validate:"min=1"`
+	Sort  string `query:"sort"`
+	Debug bool
+}
+
+func TestNexora_GenerateOpenAPI(t *testing.T) {
+	app := New()
+	app.Get("/users/{id:int}", routesTestHandler).Name("getUser").Doc(RouteDoc{
+		Summary:   "Get a user",
+		Responses: map[int]any{200: openAPITestUser{}, 404: nil},
+	})
+	app.Get("/users", routesTestHandler).Doc(RouteDoc{Query: openAPITestPage{}})
+	app.Post("/users", routesTestHandler).Doc(RouteDoc{Request: &openAPITestUser{}})
+	app.Get("/people/{age:range(18,120)}/{code:uuid}/{slug:[a-z]+}", routesTestHandler)
+	app.Get("/files/{dir?}", routesTestHandler).Name("files")
+	app.Get("/internal", routesTestHandler).Doc(RouteDoc{Hidden: true})
+	app.Handle("PURGE", "/cache", routesTestHandler)
+
+	doc, err := app.GenerateOpenAPI(OpenAPIConfig{Title: "Test", Servers: []string{"https://api.example.com"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if doc.OpenAPI != "3.1.0" || doc.Info.Title != "Test" || doc.Info.Version != "1.0.0" || doc.Servers[0].URL != "https://api.example.com" {
+		t.Errorf("document header = %+v %+v %+v", doc.OpenAPI, doc.Info, doc.Servers)
+	}
+
+	var paths []string
+	for path := range doc.Paths {
+		paths = append(paths, path)
+	}
+	want := []string{"/users/{id}", "/users", "/people/{age}/{code}/{slug}", "/files", "/files/{dir}"}
+	if len(paths) != len(want) {
+		t.Errorf("paths = %v, want %v", paths, want)
+	}
+
+	getUser := doc.Paths["/users/{id}"]["get"]
+	if getUser.OperationID != "getUser" || getUser.Summary != "Get a user" {
+		t.Errorf("operation = %+v", getUser)
+	}
+	if p := getUser.Parameters[0]; p.Name != "id" || p.In != "path" || !p.Required || p.Schema.Type != "integer" {
+		t.Errorf("id parameter = %+v", p)
+	}
+	if r := getUser.Responses["200"]; r.Content[MIMEApplicationJSON].Schema.Ref != "#/components/schemas/openAPITestUser" {
+		t.Errorf("200 response = %+v", r)
+	}
+	if r := getUser.Responses["404"]; r.Description != "Not Found" || r.Content != nil {
+		t.Errorf("404 response = %+v", r)
+	}
+
+	people := doc.Paths["/people/{age}/{code}/{slug}"]["get"].Parameters
+	if s := people[0].Schema; s.Type != "integer" || *s.Minimum != 18 || *s.Maximum != 120 {
+		t.Errorf("age schema = %+v", s)
+	}
+	if s := people[1].Schema; s.Type != "string" || s.Format != "uuid" {
+		t.Errorf("code schema = %+v", s)
+	}
+	if s := people[2].Schema; s.Pattern != "^(?:[a-z]+)$" {
+		t.Errorf("slug schema = %+v", s)
+	}
+
+	if op := doc.Paths["/files/{dir}"]["get"]; op.OperationID != "files" {
+		t.Errorf("operation ID of the full path = %q", op.OperationID)
+	}
+	if op := doc.Paths["/files"]["get"]; op.OperationID != "" || len(op.Parameters) != 0 {
+		t.Errorf("operation of the short path = %+v", op)
+	}
+
+	query := doc.Paths["/users"]["get"].Parameters
+	if len(query) != 2 || query[0].Name != "page" || query[0].In != "query" || *query[0].Schema.Minimum != 1 || query[1].Name != "sort" {
+		t.Errorf("query parameters = %+v", query)
+	}
+	if r := doc.Paths["/users"]["post"].RequestBody; r == nil || !r.Required || r.Content[MIMEApplicationJSON].Schema.Ref == "" {
+		t.Errorf("request body = %+v", r)
+	}
+
+	user := doc.Components.Schemas["openAPITestUser"]
+	if !reflect.DeepEqual(user.Required, []string{"email", "name"}) {
+		t.Errorf("required = %v", user.Required)
+	}
+	if _, ok := user.Properties["password"]; ok {
+		t.Error("unexported field documented")
+	}
+	props := user.Properties
+	if props["email"].Format != "email" || *props["name"].MinLength != 2 || *props["tags"].MaxItems != 5 ||
+		!reflect.DeepEqual(props["role"].Enum, []any{"admin", "user"}) ||
+		props["friends"].Items.Ref != "#/components/schemas/openAPITestUser" ||
+		props["created_at"].Format != "date-time" || props["id"].Format != "int64" {
+		b, _ := json.MarshalIndent(user, "", "  ")
+		t.Errorf("user schema = %s", b)
+	}
+}
+
+func TestParamSchema_Bounds(t *testing.T) {
+	tests := []struct {
+		constraint       string
+		minimum, maximum *float64
+		minLen, maxLen   *int
+	}{
+		{constraint: "range(18, 120)", minimum: ptr(18.0), maximum: ptr(120.0)},
+		{constraint: "min(10)", minimum: ptr(10.0)},
+		{constraint: "max( 100 )", minimum: ptr(0.0), maximum: ptr(100.0)},
+		{constraint: "len(6)", minLen: ptr(6), maxLen: ptr(6)},
+		{constraint: "minlen(2)", minLen: ptr(2)},
+		{constraint: "maxlen(64)", maxLen: ptr(64)},
+	}
+
+	for _, tt := range tests {
+		schema := paramSchema(ParamInfo{Name: "v", Constraint: tt.constraint})
+		if !reflect.DeepEqual(schema.Minimum, tt.minimum) || !reflect.DeepEqual(schema.Maximum, tt.maximum) ||
+			!reflect.DeepEqual(schema.MinLength, tt.minLen) || !reflect.DeepEqual(schema.MaxLength, tt.maxLen) {
+			t.Errorf("paramSchema(%s) = %+v", tt.constraint, schema)
+		}
+	}
+}
+
+func TestRouteGroup_ServeOpenAPI(t *testing.T) {
+	app := New()
+	api := app.Group("/api")
+	api.ServeOpenAPI(OpenAPIConfig{Title: "Test", UI: OpenAPIUISwagger})
+	api.Get("/ping", routesTestHandler)
+
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, httptest.NewRequest(MethodGet, "/api/openapi.json", nil))
+	if rec.Code != StatusOK || rec.Header().Get(HeaderContentType) != MIMEApplicationJSONCharsetUTF8 {
+		t.Fatalf("spec response = %d %q", rec.Code, rec.Header().Get(HeaderContentType))
+	}
+
+	var doc OpenAPIDocument
+	if err := json.Unmarshal(rec.Body.Bytes(), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if len(doc.Paths) != 1 || doc.Paths["/api/ping"]["get"] == nil {
+		t.Errorf("paths = %v, want only /api/ping", doc.Paths)
+	}
+
+	rec = httptest.NewRecorder()
+	app.ServeHTTP(rec, httptest.NewRequest(MethodGet, "/api/docs", nil))
+	if rec.Code != StatusOK || !strings.Contains(rec.Body.String(), `SwaggerUIBundle({url: "/api/openapi.json"`) ||
+		!strings.Contains(rec.Body.String(), `<script src="https://cdn.jsdelivr.net/npm/swagger-ui-dist@`+OpenAPIUISwaggerVersion+`/swagger-ui-bundle.js">`) {
+		t.Errorf("UI response = %d %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestOpenAPIUIPage(t *testing.T) {
+	page := openAPIUIPage(OpenAPIConfig{
+		UI:       OpenAPIUIRedoc,
+		UIScript: OpenAPIUIAsset{URL: "/assets/redoc.js", Integrity: "sha384-abc"},
+	}, "/openapi.json")
+	if want := `<script src="/assets/redoc.js" integrity="sha384-abc" crossorigin="anonymous"></script>`; !strings.Contains(page, want) {
+		t.Errorf("page = %s, want %s", page, want)
+	}
+
+	page = openAPIUIPage(OpenAPIConfig{UI: OpenAPIUIRedoc}, "/openapi.json")
+	if want := `<script src="https://cdn.jsdelivr.net/npm/redoc@` + OpenAPIUIRedocVersion + `/bundles/redoc.standalone.js"></script>`; !strings.Contains(page, want) {
+		t.Errorf("page = %s, want %s", page, want)
+	}
+}
+
+func TestNexora_GenerateOpenAPICollision(t *testing.T) {
+	app := New()
+	app.ServeOpenAPI(OpenAPIConfig{})
+	app.Get("/report", routesTestHandler).Headers("Accept", "text/csv").Name("csv")
+	app.Get("/report", routesTestHandler).Name("report")
+	app.Get("/items/{id:int}", routesTestHandler)
+	app.Get("/items/{id:uuid}", routesTestHandler).Doc(RouteDoc{Hidden: true})
+
+	doc, err := app.GenerateOpenAPI(OpenAPIConfig{})
+	if err == nil || !strings.Contains(err.Error(), "route GET /report registered at ") || !strings.Contains(err.Error(), "openapi_test.go:") {
+		t.Fatalf("GenerateOpenAPI() error = %v, want the collision of /report", err)
+	}
+	if op := doc.Paths["/report"]["get"]; op == nil || op.OperationID != "csv" {
+		t.Errorf("operation of /report = %+v, want the first route", op)
+	}
+
+	// The document is served, and the collision logged
+	var logs bytes.Buffer
+	defer log.SetOutput(log.Writer())
+	log.SetOutput(&logs)
+
+	for range 2 {
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, httptest.NewRequest(MethodGet, "/openapi.json", nil))
+		var served OpenAPIDocument
+		if err := json.Unmarshal(rec.Body.Bytes(), &served); rec.Code != StatusOK || err != nil || served.Paths["/report"]["get"].OperationID != "csv" {
+			t.Errorf("spec response = %d %s", rec.Code, rec.Body.String())
+		}
+	}
+	if n := strings.Count(logs.String(), "route GET /report registered at "); n != 1 {
+		t.Errorf("logged %d collisions, want 1: %s", n, logs.String())
+	}
+}
@@ -0,0 +1,202 @@
+package nexora
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// ParamInt returns the value of a route parameter as an int.
+//
+// If the parameter is not present and a defaultValue is provided, the first
+// element of defaultValue is returned instead. A missing parameter without
+// default or a value that is not an int results in an *HTTPError with status
+// 400, which matches ErrBadRequest with errors.Is. Values of parameters
+// declared as {name:int} are parsed when the route is matched, so the
+// conversion is not repeated.
+//
+// Example:
+//
+//	app.Get("/users/{id:int}", func(c *nexora.Context) error {
+//		id, err := c.ParamInt("id")
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	})
+func (c *Context) ParamInt(name string, defaultValue ...int) (int, error) {
+	return ParamAs(c, name, defaultValue...)
+}
+
+// ParamInt64 returns the value of a route parameter as an int64.
+// See ParamInt for the handling of missing and invalid values.
+func (c *Context) ParamInt64(name string, defaultValue ...int64) (int64, error) {
+	return ParamAs(c, name, defaultValue...)
+}
+
+// ParamUint returns the value of a route parameter as a uint.
+// See ParamInt for the handling of missing and invalid values.
+func (c *Context) ParamUint(name string, defaultValue ...uint) (uint, error) {
+	return ParamAs(c, name, defaultValue...)
+}
+
+// ParamFloat returns the value of a route parameter as a float64.
+// See ParamInt for the handling of missing and invalid values.
+func (c *Context) ParamFloat(name string, defaultValue ...float64) (float64, error) {
+	return ParamAs(c, name, defaultValue...)
+}
+
+// ParamBool returns the value of a route parameter as a bool, accepting the
+// values of strconv.ParseBool. See ParamInt for the handling of missing and
+// invalid values.
+func (c *Context) ParamBool(name string, defaultValue ...bool) (bool, error) {
+	return ParamAs(c, name, defaultValue...)
+}
+
+// ParamUUID returns the value of a route parameter if it is a UUID in its
+// canonical form, e.g. "123e4567-e89b-12d3-a456-426614174000". See ParamInt
+// for the handling of missing and invalid values.
+func (c *Context) ParamUUID(name string, defaultValue ...string) (string, error) {
+	return typedValue(c.ParamExists, "parameter", name, parseUUID, defaultValue)
+}
+
+// ParamTime returns the value of a route parameter parsed as a time with the
+// given layout, e.g. time.DateOnly. See ParamInt for the handling of missing
+// and invalid values.
+func (c *Context) ParamTime(name, layout string, defaultValue ...time.Time) (time.Time, error) {
+	parse := func(s string) (time.Time, error) { return time.Parse(layout, s) }
+	return typedValue(c.ParamExists, "parameter", name, parse, defaultValue)
+}
+
+// ParamAs returns the value of a route parameter converted to T. T can be a
+// string, bool, integer or float type, a time.Duration, a slice of bytes, or
+// a type whose pointer implements encoding.TextUnmarshaler. See
+// Context.ParamInt for the handling of missing and invalid values.
+//
+// Example:
+//
+//	id, err := nexora.ParamAs[uint32](c, "id")
+func ParamAs[T any](c *Context, name string, defaultValue ...T) (T, error) {
+	if v, ok := c.params.typed(name).(T); ok {
+		return v, nil
+	}
+	return typedValue(c.ParamExists, "parameter", name, convertValue[T], defaultValue)
+}
+
+// QueryInt returns the first value of a query parameter as an int.
+//
+// If the parameter is not present and a defaultValue is provided, the first
+// element of defaultValue is returned instead. A missing parameter without
+// default or a value that is not an int results in an *HTTPError with status
+// 400.
+//
+// Example:
+//
+//	page, err := c.QueryInt("page", 1)
+func (c *Context) QueryInt(key string, defaultValue ...int) (int, error) {
+	return QueryAs(c, key, defaultValue...)
+}
+
+// QueryInt64 returns the first value of a query parameter as an int64.
+// See QueryInt for the handling of missing and invalid values.
+func (c *Context) QueryInt64(key string, defaultValue ...int64) (int64, error) {
+	return QueryAs(c, key, defaultValue...)
+}
+
+// QueryUint returns the first value of a query parameter as a uint.
+// See QueryInt for the handling of missing and invalid values.
+func (c *Context) QueryUint(key string, defaultValue ...uint) (uint, error) {
+	return QueryAs(c, key, defaultValue...)
+}
+
+// QueryFloat returns the first value of a query parameter as a float64.
+// See QueryInt for the handling of missing and invalid values.
+func (c *Context) QueryFloat(key string, defaultValue ...float64) (float64, error) {
+	return QueryAs(c, key, defaultValue...)
+}
+
+// QueryBool returns the first value of a query parameter as a bool,
+// accepting the values of strconv.ParseBool. See QueryInt for the handling
+// of missing and invalid values.
+func (c *Context) QueryBool(key string, defaultValue ...bool) (bool, error) {
+	return QueryAs(c, key, defaultValue...)
+}
+
+// QueryUUID returns the first value of a query parameter if it is a UUID in
+// its canonical form. See QueryInt for the handling of missing and invalid
+// values.
+func (c *Context) QueryUUID(key string, defaultValue ...string) (string, error) {
+	return typedValue(c.QueryExists, "query parameter", key, parseUUID, defaultValue)
+}
+
+// QueryTime returns the first value of a query parameter parsed as a time
+// with the given layout. See QueryInt for the handling of missing and invalid
+// values.
+func (c *Context) QueryTime(key, layout string, defaultValue ...time.Time) (time.Time, error) {
+	parse := func(s string) (time.Time, error) { return time.Parse(layout, s) }
+	return typedValue(c.QueryExists, "query parameter", key, parse, defaultValue)
+}
+
+// QueryAs returns the first value of a query parameter converted to T.
+// The supported types are those of ParamAs. See Context.QueryInt for the
+// handling of missing and invalid values.
+//
+// Example:
+//
+//	timeout, err := nexora.QueryAs(c, "timeout", 5*time.Second)
+func QueryAs[T any](c *Context, key string, defaultValue ...T) (T, error) {
+	return typedValue(c.QueryExists, "query parameter", key, convertValue[T], defaultValue)
+}
+
+// typedValue parses the value returned by lookup. kind names the parameter
+// in errors.
+func typedValue[T any](lookup func(string) (string, bool), kind, name string, parse func(string) (T, error), defaultValue []T) (T, error) {
+	var zero T
+	value, ok := lookup(name)
+	if !ok {
+		if len(defaultValue) > 0 {
+			return defaultValue[0], nil
+		}
+		return zero, NewHTTPError(StatusBadRequest, fmt.Sprintf("missing %s %q", kind, name))
+	}
+
+	v, err := parse(value)
+	if err != nil {
+		return zero, NewHTTPError(StatusBadRequest, fmt.Sprintf("invalid value %q for %s %q", value, kind, name))
+	}
+	return v, nil
+}
+
+// convertValue converts a string to T like the fields of bound structs.
+func convertValue[T any](value string) (T, error) {
+	var v T
+	err := setField(reflect.ValueOf(&v).Elem(), []string{value})
+	return v, err
+}
+
+// parseUUID returns s if it is a UUID in its canonical form.
+func parseUUID(s string) (string, error) {
+	if len(s) != 36 {
+		return "", errInvalidUUID
+	}
+	for i := 0; i < len(s); i++ {
+		switch i {
+		case 8, 13, 18, 23:
+			if s[i] != '-' {
+				return "", errInvalidUUID
+			}
+		default:
+			if !isHex(s[i]) {
+				return "", errInvalidUUID
+			}
+		}
+	}
+	return s, nil
+}
+
+var errInvalidUUID = errors.New("nexora: invalid UUID")
+
+func isHex(c byte) bool {
+	return '0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F'
+}
@@ -0,0 +1,156 @@
+package nexora
+
+import (
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestContext_TypedParams(t *testing.T) {
+	app := New()
+	app.Get("/users/{id:int}/{score:float64}/{active:bool}", func(c *Context) error {
+		if _, ok := c.params.typed("id").(int); !ok {
+			t.Errorf("id was not parsed when the route was matched: %v", c.params)
+		}
+		id, err := c.ParamInt("id")
+		if err != nil {
+			return err
+		}
+		id64, err := c.ParamInt64("id")
+		if err != nil {
+			return err
+		}
+		score, err := c.ParamFloat("score")
+		if err != nil {
+			return err
+		}
+		active, err := c.ParamBool("active")
+		if err != nil {
+			return err
+		}
+		return c.SendString(fmt.Sprint(id, id64, score, active))
+	})
+	app.Get("/files/{name}", func(c *Context) error {
+		n, err := c.ParamUint("name")
+		if err != nil {
+			return err
+		}
+		return c.SendString(fmt.Sprint(n))
+	})
+	app.Get("/events/{id}/{day}", func(c *Context) error {
+		id, err := c.ParamUUID("id")
+		if err != nil {
+			return err
+		}
+		day, err := c.ParamTime("day", time.DateOnly)
+		if err != nil {
+			return err
+		}
+		return c.SendString(id + " " + day.Weekday().String())
+	})
+
+	tests := []struct {
+		target string
+		code   int
+		body   string
+	}{
+		{"/users/42/1.5/true", StatusOK, "42 42 1.5 true"},
+		{"/users/99999999999999999999/1.5/true", StatusNotFound, ""},
+		{"/files/7", StatusOK, "7"},
+		{"/files/-7", StatusBadRequest, ""},
+		{"/files/readme", StatusBadRequest, ""},
+		{"/events/123e4567-e89b-12d3-a456-426614174000/2025-06-02", StatusOK, "123e4567-e89b-12d3-a456-426614174000 Monday"},
+		{"/events/123e4567e89b12d3a456426614174000abcd/2025-06-02", StatusBadRequest, ""},
+		{"/events/123e4567-e89b-12d3-a456-426614174000/02-06-2025", StatusBadRequest, ""},
+	}
+
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, httptest.NewRequest("GET", tt.target, nil))
+
+		if rec.Code != tt.code || (tt.body != "" && rec.Body.String() != tt.body) {
+			t.Errorf("GET %s = %d %q, want %d %q", tt.target, rec.Code, rec.Body.String(), tt.code, tt.body)
+		}
+	}
+}
+
+func TestParamAs(t *testing.T) {
+	c := newContext(New())
+	c.params = pathParams{{key: "id", value: "12"}, {key: "ttl", value: "1m30s"}, {key: "name", value: "gopher"}}
+
+	if v, err := ParamAs[uint8](c, "id"); err != nil || v != 12 {
+		t.Errorf("ParamAs[uint8](id) = %v, %v", v, err)
+	}
+	if v, err := ParamAs[time.Duration](c, "ttl"); err != nil || v != 90*time.Second {
+		t.Errorf("ParamAs[time.Duration](ttl) = %v, %v", v, err)
+	}
+	if v, err := ParamAs[string](c, "name"); err != nil || v != "gopher" {
+		t.Errorf("ParamAs[string](name) = %v, %v", v, err)
+	}
+	if v, err := ParamAs(c, "missing", 5); err != nil || v != 5 {
+		t.Errorf("ParamAs(missing, 5) = %v, %v", v, err)
+	}
+
+	_, err := ParamAs[int](c, "missing")
+	if !errors.Is(err, ErrBadRequest) {
+		t.Errorf("ParamAs[int](missing) error = %v, want ErrBadRequest", err)
+	}
+	_, err = ParamAs[int](c, "name")
+	if !errors.Is(err, ErrBadRequest) || errors.Is(err, ErrNotFound) {
+		t.Errorf("ParamAs[int](name) error = %v, want ErrBadRequest", err)
+	}
+
+	// A parsed value of another type is parsed again
+	c.params[0].typed = int64(12)
+	if v, err := c.ParamInt("id"); err != nil || v != 12 {
+		t.Errorf("ParamInt(id) = %v, %v", v, err)
+	}
+	c.params[0].typed = int64(13)
+	if v, err := c.ParamInt64("id"); err != nil || v != 13 {
+		t.Errorf("ParamInt64(id) = %v, %v, want the parsed value", v, err)
+	}
+}
+
+func TestContext_TypedQuery(t *testing.T) {
+	c := newContext(New())
+	c.init(httptest.NewRequest("GET", "/?page=3&limit=-1&ratio=0.25&debug=1&since=2025-01-02&id=123e4567-e89b-12d3-a456-426614174000&timeout=2s", nil), httptest.NewRecorder())
+
+	if v, err := c.QueryInt("page"); err != nil || v != 3 {
+		t.Errorf("QueryInt(page) = %v, %v", v, err)
+	}
+	if v, err := c.QueryInt("offset", 10); err != nil || v != 10 {
+		t.Errorf("QueryInt(offset, 10) = %v, %v", v, err)
+	}
+	if v, err := c.QueryInt64("limit"); err != nil || v != -1 {
+		t.Errorf("QueryInt64(limit) = %v, %v", v, err)
+	}
+	if _, err := c.QueryUint("limit"); err == nil {
+		t.Error("QueryUint(limit) returned no error for a negative value")
+	}
+	if v, err := c.QueryFloat("ratio"); err != nil || v != 0.25 {
+		t.Errorf("QueryFloat(ratio) = %v, %v", v, err)
+	}
+	if v, err := c.QueryBool("debug"); err != nil || !v {
+		t.Errorf("QueryBool(debug) = %v, %v", v, err)
+	}
+	if v, err := c.QueryTime("since", time.DateOnly); err != nil || v.Day() != 2 {
+		t.Errorf("QueryTime(since) = %v, %v", v, err)
+	}
+	if v, err := c.QueryUUID("id"); err != nil || v != "123e4567-e89b-12d3-a456-426614174000" {
+		t.Errorf("QueryUUID(id) = %v, %v", v, err)
+	}
+	if v, err := QueryAs[time.Duration](c, "timeout"); err != nil || v != 2*time.Second {
+		t.Errorf("QueryAs[time.Duration](timeout) = %v, %v", v, err)
+	}
+
+	_, err := c.QueryInt("missing")
+	if !errors.Is(err, ErrBadRequest) {
+		t.Errorf("QueryInt(missing) error = %v, want ErrBadRequest", err)
+	}
+	_, err = c.QueryUUID("page")
+	if !errors.Is(err, ErrBadRequest) {
+		t.Errorf("QueryUUID(page) error = %v, want ErrBadRequest", err)
+	}
+}
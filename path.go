@@ -8,9 +8,11 @@
 package nexora
 
 import (
-	"fmt"
-	"regexp"
+	"errors"
+	"math"
+	"strconv"
 	"strings"
+	"unicode/utf8"
 
 	gstrings "github.com/savsgio/gotils/strings"
 )
@@ -91,34 +93,15 @@ walk:
 	}
 }
 
-// constraintsType maps supported parameter types to their corresponding regex patterns.
-// These are strict patterns that match common Go primitive types.
+// constraintsType maps the parameter types without a Go equivalent to their
+// regex patterns. The types with one are in typedConstraints.
 var constraintsType = map[string]string{
-	// Signed integers with strict ranges where practical
-	"int":   `-?\d+`,
-	"int8":  `-?(?:12[0-7]|1[01]\d|[1-9]?\d)`,                       // -128 to 127
-	"int16": `-?(?:3276[0-7]|327[0-5]\d|32[0-6]\d{2}|[12]?\d{1,3})`, // -32768 to 32767
-	"int32": `-?\d+`,                                                // full range too large for practical regex
-	"int64": `-?\d+`,
-
-	// Unsigned integers
-	"uint":   `\d+`,
-	"uint8":  `(?:25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)`,                             // 0 to 255
-	"uint16": `(?:6553[0-5]|655[0-2]\d|65[0-4]\d{2}|6[0-4]\d{3}|[1-5]?\d{1,4})`, // 0 to 65535
-	"uint32": `\d+`,
-	"uint64": `\d+`,
-
-	// Floats
-	"float32": `[-+]?\d*\.?\d+`,
-	"float64": `[-+]?\d*\.?\d+`,
-
 	// Common utility types
 	"string": `[^/]+`,
 	"slug":   `[A-Za-z0-9_-]+`,
 	"uuid":   `[0-9a-fA-F-]{36}`,
 	"alpha":  `[A-Za-z]+`,
 	"alnum":  `[A-Za-z0-9]+`,
-	"bool":   `(true|false|0|1)`,
 	"path":   `.*`,
 
 	// other
@@ -132,151 +115,187 @@ var constraintsType = map[string]string{
 	"base64":   `[A-Za-z0-9+/=]+`,
 }
 
-// paramRegex matches path parameters of the form:
-// {name:type}, {name:type(extra)}, {name}, etc.
-var paramRegex = regexp.MustCompile(`{(\w+)(?::([a-zA-Z0-9_]+)(?:\(([0-9, ]+)\))?)?}`)
+// constraint is a resolved parameter constraint. Values must match the
+// pattern and, if it is set, be accepted by parse, which allows exact bounds
+// without huge regular expressions. parse returns the value converted to the
+// Go type of the constraint, or nil if it has none. It runs once when a
+// route is matched, and the converted value is kept with the parameter.
+type constraint struct {
+	pattern string
+	parse   func(value string) (any, error)
+}
 
-// parseConstraintsRoute parses a route pattern and converts
-// typed parameters into their corresponding regex patterns.
-//
-// Supported forms:
+// constraintResolver resolves a constraint as written in a path.
+// See resolveConstraint.
+type constraintResolver func(spec string) (constraint, bool)
+
+// typedConstraints maps the parameter types with a Go equivalent to
+// constraints converting values into it, which checks that they fit in it.
+var typedConstraints = map[string]constraint{
+	"int":     {pattern: `-?\d+`, parse: parseIntAs[int](strconv.IntSize)},
+	"int8":    {pattern: `-?\d+`, parse: parseIntAs[int8](8)},
+	"int16":   {pattern: `-?\d+`, parse: parseIntAs[int16](16)},
+	"int32":   {pattern: `-?\d+`, parse: parseIntAs[int32](32)},
+	"int64":   {pattern: `-?\d+`, parse: parseIntAs[int64](64)},
+	"uint":    {pattern: `\d+`, parse: parseUintAs[uint](strconv.IntSize)},
+	"uint8":   {pattern: `\d+`, parse: parseUintAs[uint8](8)},
+	"uint16":  {pattern: `\d+`, parse: parseUintAs[uint16](16)},
+	"uint32":  {pattern: `\d+`, parse: parseUintAs[uint32](32)},
+	"uint64":  {pattern: `\d+`, parse: parseUintAs[uint64](64)},
+	"float32": {pattern: `[-+]?\d*\.?\d+`, parse: func(s string) (any, error) { f, err := strconv.ParseFloat(s, 32); return float32(f), err }},
+	"float64": {pattern: `[-+]?\d*\.?\d+`, parse: func(s string) (any, error) { return strconv.ParseFloat(s, 64) }},
+	"bool":    {pattern: `true|false|0|1`, parse: func(s string) (any, error) { return strconv.ParseBool(s) }},
+}
+
+// resolveConstraint resolves a constraint as written in a path, e.g. "int"
+// or "range(18,120)". It returns false if the constraint is not a known type,
+// in which case it is a regular expression.
 //
-//	/user/{id:int}
-//	/range/{age:range(18,120)}
-//	/len/{code:len(6)}
-//	/min/{val:min(10)}
-//	/max/{val:max(100)}
+// Supported forms besides the types of typedConstraints and constraintsType:
 //
-// Unknown types are left untouched for further handling.
+//	/user/{id:int}              integer types check the range of the type
+//	/range/{age:range(18,120)}  non-negative integer between 18 and 120
+//	/min/{val:min(10)}          non-negative integer of at least 10
+//	/max/{val:max(100)}         non-negative integer of at most 100
+//	/len/{code:len(6)}          exactly 6 characters
+//	/len/{name:minlen(2)}       at least 2 characters
+//	/len/{name:maxlen(64)}      at most 64 characters
 //
-// NOTE: `range()`, `min()`, and `max()` apply ONLY to integer types.
-func parseConstraintsRoute(route string) string {
-	segments := strings.Split(route, "/")
-	out := make([]string, 0, len(segments))
+// It panics if the arguments of a constraint are invalid.
+func resolveConstraint(spec string) (constraint, bool) {
+	name, args := splitConstraint(spec)
 
-	for _, seg := range segments {
-		if seg == "" {
-			continue
+	if args == "" {
+		if c, ok := typedConstraints[name]; ok {
+			return c, true
+		}
+		if pattern, ok := constraintsType[name]; ok {
+			return constraint{pattern: pattern}, true
 		}
+		return constraint{}, false
+	}
 
-		// Replace each {param:type(...)} occurrence
-		newSeg := paramRegex.ReplaceAllStringFunc(seg, func(s string) string {
-			m := paramRegex.FindStringSubmatch(s)
-			if m == nil {
-				return s
+	switch name {
+	case "range", "min", "max":
+		min, max := int64(0), int64(math.MaxInt64)
+		switch name {
+		case "range":
+			lo, hi, ok := strings.Cut(args, ",")
+			min, max = constraintArg(spec, lo), constraintArg(spec, hi)
+			if !ok {
+				panicf("nexora: invalid constraint %q", spec)
 			}
-			key, typ, extra := m[1], m[2], m[3]
-
-			// Handle built-in constraints
-			switch typ {
-			case "max":
-				if extra != "" {
-					maxVal := parseInt(extra)
-					if maxVal < 0 {
-						panicf("nexora: invalid max() value in route: %s", s)
-					}
-					return fmt.Sprintf("{%s:%s}", key, generateMaxRegex(maxVal))
-				}
-			case "min":
-				if extra != "" {
-					minVal := parseInt(extra)
-					if minVal < 0 {
-						panicf("nexora: invalid min() value in route: %s", s)
-					}
-					return fmt.Sprintf("{%s:%s}", key, generateMinRegex(minVal))
-				}
-			case "len":
-				if extra != "" {
-					l := parseInt(extra)
-					if l <= 0 || l > 1024 {
-						panicf("nexora: invalid len() value in route: %s", s)
-					}
-					rgx := fmt.Sprintf(`[^/]{%d}`, l)
-					return fmt.Sprintf("{%s:%s}", key, rgx)
-				}
-			case "range":
-				if extra != "" {
-					parts := strings.Split(extra, ",")
-					if len(parts) == 2 {
-						min := parseInt(strings.TrimSpace(parts[0]))
-						max := parseInt(strings.TrimSpace(parts[1]))
-						if min < 0 || max < 0 || max < min {
-							panicf("nexora: invalid range() values in route: %s", s)
-						}
-						return fmt.Sprintf("{%s:%s}", key, generateRangeRegex(min, max))
-					} else {
-						panicf("nexora: invalid range() format in route: %s", s)
-					}
-				}
-			default:
-				if typ != "" {
-					if regex, ok := constraintsType[typ]; ok {
-						return fmt.Sprintf("{%s:%s}", key, regex)
-					}
-				}
-			}
-			// No type provided: leave as-is
-			return s
-		})
-
-		out = append(out, newSeg)
+		case "min":
+			min = constraintArg(spec, args)
+		case "max":
+			max = constraintArg(spec, args)
+		}
+		if min < 0 || max < min {
+			panicf("nexora: invalid constraint %q", spec)
+		}
+		return constraint{pattern: `\d+`, parse: parseIntBounds(min, max)}, true
+	case "len", "minlen", "maxlen":
+		n := constraintArg(spec, args)
+		if n < 0 || name == "len" && n == 0 {
+			panicf("nexora: invalid constraint %q", spec)
+		}
+		min, max := n, n
+		if name == "minlen" {
+			max = math.MaxInt64
+		} else if name == "maxlen" {
+			min = 0
+		}
+		return constraint{pattern: `[^/]+`, parse: checkLen(min, max)}, true
 	}
 
-	return "/" + strings.Join(out, "/")
+	return constraint{}, false
 }
 
-// generateRangeRegex builds a regex that matches any integer between min and max inclusive.
-// If the range is too large, it falls back to a generic \d+ for performance.
-func generateRangeRegex(min, max int) string {
-	if max < min {
-		panicf("nexora: invalid range, max (%d) is less than min (%d)", max, min)
+// splitConstraint splits a constraint as written in a path into its name
+// and the arguments between parentheses, e.g. "range" and "18,120".
+func splitConstraint(spec string) (name, args string) {
+	if i := strings.IndexByte(spec, '('); i > 0 && strings.HasSuffix(spec, ")") {
+		return spec[:i], spec[i+1 : len(spec)-1]
 	}
-	if max-min > 1000 {
-		return `\d+`
+	return spec, ""
+}
+
+// isConstraintName reports whether the constraint is written as a type,
+// e.g. "sku" or "date(2006-01-02)", rather than a regular expression.
+func isConstraintName(spec string) bool {
+	name, _ := splitConstraint(spec)
+	if name == "" {
+		return false
 	}
-	patterns := make([]string, 0, max-min+1)
-	for i := min; i <= max; i++ {
-		patterns = append(patterns, fmt.Sprintf("%d", i))
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		if c != '_' && !('a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || i > 0 && '0' <= c && c <= '9') {
+			return false
+		}
 	}
-	return "^(" + strings.Join(patterns, "|") + ")$"
+	return true
 }
 
-// generateMinRegex builds a regex that matches any integer >= min, up to min+500.
-// Beyond that, it falls back to a generic pattern to avoid huge regex.
-func generateMinRegex(min int) string {
-	if min < 0 {
-		min = 0
+// constraintArg parses an integer argument of a constraint.
+func constraintArg(spec, arg string) int64 {
+	n, err := strconv.ParseInt(strings.TrimSpace(arg), 10, 64)
+	if err != nil {
+		panicf("nexora: invalid constraint %q", spec)
 	}
-	if min > 100000 {
-		return `\d+`
+	return n
+}
+
+// matchConstraint reports whether the value satisfies the constraint.
+func matchConstraint(resolve constraintResolver, spec, value string) bool {
+	c, ok := resolve(spec)
+	if !ok {
+		c.pattern = spec
+	} else if c.pattern == "" {
+		c.pattern = `[^/]+`
 	}
-	patterns := make([]string, 0, 501)
-	for i := min; i <= min+500; i++ {
-		patterns = append(patterns, fmt.Sprintf("%d", i))
+	if !compileRule(c.pattern, true).MatchString(value) {
+		return false
+	}
+	if c.parse != nil {
+		if _, err := c.parse(value); err != nil {
+			return false
+		}
 	}
-	return "^(" + strings.Join(patterns, "|") + ")$"
+	return true
 }
 
-// generateMaxRegex builds a regex that matches any integer from 0 up to max.
-// Beyond 1000, it falls back to a generic pattern.
-func generateMaxRegex(max int) string {
-	if max < 0 {
-		max = 0
+func parseIntAs[T ~int | ~int8 | ~int16 | ~int32 | ~int64](bitSize int) func(string) (any, error) {
+	return func(s string) (any, error) {
+		n, err := strconv.ParseInt(s, 10, bitSize)
+		return T(n), err
 	}
-	if max > 1000 {
-		return `\d+`
+}
+
+func parseUintAs[T ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64](bitSize int) func(string) (any, error) {
+	return func(s string) (any, error) {
+		n, err := strconv.ParseUint(s, 10, bitSize)
+		return T(n), err
 	}
-	patterns := make([]string, 0, max+1)
-	for i := 0; i <= max; i++ {
-		patterns = append(patterns, fmt.Sprintf("%d", i))
+}
+
+func parseIntBounds(min, max int64) func(string) (any, error) {
+	return func(s string) (any, error) {
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err == nil && (n < min || n > max) {
+			err = errConstraint
+		}
+		return n, err
 	}
-	return "^(" + strings.Join(patterns, "|") + ")$"
 }
 
-// parseInt parses a string into an int, trimming whitespace.
-// Returns 0 if parsing fails.
-func parseInt(s string) int {
-	var v int
-	fmt.Sscanf(strings.TrimSpace(s), "%d", &v)
-	return v
+func checkLen(min, max int64) func(string) (any, error) {
+	return func(s string) (any, error) {
+		if n := int64(utf8.RuneCountInString(s)); n < min || n > max {
+			return nil, errConstraint
+		}
+		return nil, nil
+	}
 }
+
+// errConstraint is returned for values which don't satisfy a constraint.
+var errConstraint = errors.New("nexora: value does not satisfy the constraint")
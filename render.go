@@ -0,0 +1,117 @@
+package nexora
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"regexp"
+	"strconv"
+
+	"github.com/valyala/bytebufferpool"
+)
+
+// jsonpCallbackRegex matches valid JSONP callback names such as "cb" or "jQuery.fn_1".
+var jsonpCallbackRegex = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$.]*$`)
+
+// JSON encodes v as JSON and sends it with the given status code.
+//
+// Example:
+//
+//	return c.JSON(200, map[string]any{"id": 1})
+func (c *Context) JSON(code int, v any) error {
+	return c.encodeJSON(code, MIMEApplicationJSONCharsetUTF8, v, "", "")
+}
+
+// IndentedJSON encodes v as indented JSON and sends it with the given status code.
+// It is meant for debugging, as the output is larger than that of JSON.
+func (c *Context) IndentedJSON(code int, v any) error {
+	return c.encodeJSON(code, MIMEApplicationJSONCharsetUTF8, v, "", "  ")
+}
+
+// JSONP encodes v as JSON wrapped in a call to the given callback function and
+// sends it with the given status code.
+// An *HTTPError with status 400 is returned if callback is not a valid
+// JavaScript identifier.
+//
+// Example:
+//
+//	return c.JSONP(200, c.Query("callback"), data)
+func (c *Context) JSONP(code int, callback string, v any) error {
+	if !jsonpCallbackRegex.MatchString(callback) {
+		return NewHTTPError(StatusBadRequest, "invalid JSONP callback")
+	}
+	return c.encodeJSON(code, MIMEApplicationJavaScriptCharsetUTF8, v, callback, "")
+}
+
+// encodeJSON encodes v into a pooled buffer, optionally wrapped in a JSONP
+// callback, and sends it.
+func (c *Context) encodeJSON(code int, contentType string, v any, callback, indent string) error {
+	buf := bytebufferpool.Get()
+	defer bytebufferpool.Put(buf)
+
+	if callback != "" {
+		buf.WriteString("/**/ typeof " + callback + " === 'function' && " + callback + "(")
+	}
+
+	enc := json.NewEncoder(buf)
+	if indent != "" {
+		enc.SetIndent("", indent)
+	}
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+	// Drop the newline added by Encode
+	buf.B = buf.B[:len(buf.B)-1]
+
+	if callback != "" {
+		buf.WriteString(");")
+	}
+
+	return c.Blob(code, contentType, buf.B)
+}
+
+// XML encodes v as XML, prefixed with the standard XML header, and sends it
+// with the given status code.
+func (c *Context) XML(code int, v any) error {
+	buf := bytebufferpool.Get()
+	defer bytebufferpool.Put(buf)
+
+	buf.WriteString(xml.Header)
+	if err := xml.NewEncoder(buf).Encode(v); err != nil {
+		return err
+	}
+
+	return c.Blob(code, MIMEApplicationXMLCharsetUTF8, buf.B)
+}
+
+// HTML sends the given HTML with the given status code.
+func (c *Context) HTML(code int, html string) error {
+	buf := bytebufferpool.Get()
+	defer bytebufferpool.Put(buf)
+
+	buf.WriteString(html)
+	return c.Blob(code, MIMETextHTMLCharsetUTF8, buf.B)
+}
+
+// Blob sends b with the given status code and content type.
+// The Content-Length header is set from the length of b.
+func (c *Context) Blob(code int, contentType string, b []byte) error {
+	c.SetContentType(contentType)
+	c.SetHeader(HeaderContentLength, strconv.Itoa(len(b)))
+	c.writer.WriteHeader(code)
+	_, err := c.writer.Write(b)
+	return err
+}
+
+// Stream copies everything from r to the response with the given status code
+// and content type. If r is an io.Closer, it is closed once the copy is done.
+func (c *Context) Stream(code int, contentType string, r io.Reader) error {
+	if closer, ok := r.(io.Closer); ok {
+		defer closer.Close()
+	}
+
+	c.SetContentType(contentType)
+	c.writer.WriteHeader(code)
+	_, err := io.Copy(c.response, r)
+	return err
+}
@@ -0,0 +1,136 @@
+package nexora
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func newRenderContext() (*Context, *httptest.ResponseRecorder) {
+	rec := httptest.NewRecorder()
+	ctx := newContext(nil)
+	ctx.init(httptest.NewRequest(http.MethodGet, "/", nil), rec)
+	return ctx, rec
+}
+
+func TestContext_JSON(t *testing.T) {
+	ctx, rec := newRenderContext()
+
+	if err := ctx.JSON(http.StatusCreated, map[string]any{"id": 1, "html": "<b>"}); err != nil {
+		t.Fatalf("JSON() error = %v", err)
+	}
+
+	if rec.Code != http.StatusCreated {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusCreated)
+	}
+	if ct := rec.Header().Get(HeaderContentType); ct != MIMEApplicationJSONCharsetUTF8 {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	want := `{"html":"\u003cb\u003e","id":1}`
+	if body := rec.Body.String(); body != want {
+		t.Errorf("body = %q, want %q", body, want)
+	}
+	if cl := rec.Header().Get(HeaderContentLength); cl != strconv.Itoa(len(want)) {
+		t.Errorf("Content-Length = %q, want %d", cl, len(want))
+	}
+}
+
+func TestContext_IndentedJSON(t *testing.T) {
+	ctx, rec := newRenderContext()
+
+	if err := ctx.IndentedJSON(http.StatusOK, map[string]int{"a": 1}); err != nil {
+		t.Fatalf("IndentedJSON() error = %v", err)
+	}
+	if body := rec.Body.String(); body != "{\n  \"a\": 1\n}" {
+		t.Errorf("body = %q", body)
+	}
+}
+
+func TestContext_JSONP(t *testing.T) {
+	ctx, rec := newRenderContext()
+
+	if err := ctx.JSONP(http.StatusOK, "cb", []int{1, 2}); err != nil {
+		t.Fatalf("JSONP() error = %v", err)
+	}
+	if ct := rec.Header().Get(HeaderContentType); ct != MIMEApplicationJavaScriptCharsetUTF8 {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	if body := rec.Body.String(); body != "/**/ typeof cb === 'function' && cb([1,2]);" {
+		t.Errorf("body = %q", body)
+	}
+
+	ctx, _ = newRenderContext()
+	err := ctx.JSONP(http.StatusOK, "alert(1);//", nil)
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != StatusBadRequest {
+		t.Errorf("JSONP() with invalid callback error = %v, want 400", err)
+	}
+}
+
+func TestContext_JSONUnsupportedValue(t *testing.T) {
+	ctx, rec := newRenderContext()
+
+	if err := ctx.JSON(http.StatusOK, make(chan int)); err == nil {
+		t.Fatal("JSON() with unsupported value should fail")
+	}
+	if rec.Body.Len() != 0 {
+		t.Errorf("body = %q, want nothing written", rec.Body.String())
+	}
+}
+
+func TestContext_XML(t *testing.T) {
+	type item struct {
+		Name string `xml:"name"`
+	}
+	ctx, rec := newRenderContext()
+
+	if err := ctx.XML(http.StatusOK, item{Name: "gopher"}); err != nil {
+		t.Fatalf("XML() error = %v", err)
+	}
+	if ct := rec.Header().Get(HeaderContentType); ct != MIMEApplicationXMLCharsetUTF8 {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	want := `<?xml version="1.0" encoding="UTF-8"?>` + "\n" + `<item><name>gopher</name></item>`
+	if body := rec.Body.String(); body != want {
+		t.Errorf("body = %q, want %q", body, want)
+	}
+}
+
+func TestContext_HTML(t *testing.T) {
+	ctx, rec := newRenderContext()
+
+	if err := ctx.HTML(http.StatusOK, "<h1>Hi</h1>"); err != nil {
+		t.Fatalf("HTML() error = %v", err)
+	}
+	if ct := rec.Header().Get(HeaderContentType); ct != MIMETextHTMLCharsetUTF8 {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	if body := rec.Body.String(); body != "<h1>Hi</h1>" {
+		t.Errorf("body = %q", body)
+	}
+}
+
+func TestContext_Blob(t *testing.T) {
+	ctx, rec := newRenderContext()
+
+	if err := ctx.Blob(http.StatusAccepted, "image/png", []byte{0x89, 'P', 'N', 'G'}); err != nil {
+		t.Fatalf("Blob() error = %v", err)
+	}
+	if rec.Code != http.StatusAccepted || rec.Header().Get(HeaderContentType) != "image/png" || rec.Body.Len() != 4 {
+		t.Errorf("Blob() wrote status %d, type %q, %d bytes", rec.Code, rec.Header().Get(HeaderContentType), rec.Body.Len())
+	}
+}
+
+func TestContext_Stream(t *testing.T) {
+	ctx, rec := newRenderContext()
+
+	if err := ctx.Stream(http.StatusOK, "text/csv", strings.NewReader("a,b\n1,2\n")); err != nil {
+		t.Fatalf("Stream() error = %v", err)
+	}
+	if rec.Header().Get(HeaderContentType) != "text/csv" || rec.Body.String() != "a,b\n1,2\n" {
+		t.Errorf("Stream() wrote type %q, body %q", rec.Header().Get(HeaderContentType), rec.Body.String())
+	}
+}
@@ -1,59 +1,520 @@
 package nexora
 
 import (
+	"bufio"
+	"io"
 	"log"
+	"net"
 	"net/http"
+	"strconv"
+
+	"github.com/valyala/bytebufferpool"
 )
 
 // ResponseWriter is a wrapper around http.ResponseWriter that
 // captures the status code and response size for logging and middleware.
-type ResponseWriter struct {
-	http.ResponseWriter      // underlying http.ResponseWriter
-	status              int  // HTTP status code
-	size                int  // number of bytes written
-	wrote               bool // whether the header has been written
+//
+// The ResponseWriter returned by NewResponseWriter and Context.ResponseWriter
+// implements exactly the optional interfaces among http.Flusher,
+// http.Hijacker, http.Pusher and io.ReaderFrom that the underlying writer
+// implements, so type assertions for them only succeed if the underlying
+// writer supports them. Unwrap returns the underlying writer for
+// http.ResponseController.
+//
+// In buffered mode, enabled with Buffer, the status and body are held back
+// until the request is done, so middleware can still change them after the
+// handler has written the response.
+type ResponseWriter interface {
+	http.ResponseWriter
+
+	// Status returns the HTTP status code of the response.
+	Status() int
+
+	// Size returns the total number of bytes written to the response body.
+	Size() int
+
+	// Hijacked reports whether the connection has been hijacked.
+	Hijacked() bool
+
+	// Buffer enables buffered mode.
+	Buffer()
+
+	// Buffered reports whether the response is buffered and not yet sent.
+	Buffered() bool
+
+	// Body returns the buffered response body, or nil if the response is
+	// not buffered.
+	Body() []byte
+
+	// Reset discards the buffered body and status.
+	Reset() bool
+
+	// Before registers a function that is called right before the header
+	// is sent.
+	Before(fn func())
+
+	// After registers a function that is called once the response is
+	// complete.
+	After(fn func())
+
+	// Unwrap returns the underlying http.ResponseWriter.
+	Unwrap() http.ResponseWriter
+}
+
+// responseWriter implements ResponseWriter. It has no method of the optional
+// interfaces, which are added by wrapResponseWriter.
+type responseWriter struct {
+	http.ResponseWriter                            // underlying http.ResponseWriter
+	status              int                        // HTTP status code
+	size                int                        // number of bytes written
+	wrote               bool                       // whether the header has been written
+	hijacked            bool                       // whether the connection has been hijacked
+	buffer              *bytebufferpool.ByteBuffer // body held back in buffered mode
+	before, after       []func()                   // hooks run before the header is sent and after the response
 }
 
-// Ensure ResponseWriter implements http.ResponseWriter.
-var _ http.ResponseWriter = (*ResponseWriter)(nil)
+// Ensure the ResponseWriter implementations implement the optional
+// interfaces of net/http they are meant to.
+var (
+	_ ResponseWriter = (*responseWriter)(nil)
+	_ http.Flusher   = flushWriter{}
+	_ http.Hijacker  = hijackWriter{}
+	_ http.Pusher    = pushWriter{}
+	_ io.ReaderFrom  = readFromWriter{}
+)
 
 // NewResponseWriter creates a new wrapped ResponseWriter.
 // It sets the default status code to 200.
-func NewResponseWriter(w http.ResponseWriter) *ResponseWriter {
-	return &ResponseWriter{
+func NewResponseWriter(w http.ResponseWriter) ResponseWriter {
+	return wrapResponseWriter(&responseWriter{
 		ResponseWriter: w,
 		status:         200,
+	})
+}
+
+// The optional interfaces which may be implemented by the underlying writer.
+const (
+	optionalFlusher = 1 << iota
+	optionalHijacker
+	optionalPusher
+	optionalReaderFrom
+)
+
+// wrapResponseWriter returns r with the methods of the optional interfaces
+// implemented by its underlying writer.
+func wrapResponseWriter(r *responseWriter) ResponseWriter {
+	var optional int
+	switch r.ResponseWriter.(type) {
+	case http.Flusher, interface{ FlushError() error }:
+		optional |= optionalFlusher
+	}
+	if _, ok := r.ResponseWriter.(http.Hijacker); ok {
+		optional |= optionalHijacker
+	}
+	if _, ok := r.ResponseWriter.(http.Pusher); ok {
+		optional |= optionalPusher
 	}
+	if _, ok := r.ResponseWriter.(io.ReaderFrom); ok {
+		optional |= optionalReaderFrom
+	}
+
+	f, h, p, rf := flushWriter{r}, hijackWriter{r}, pushWriter{r}, readFromWriter{r}
+	switch optional {
+	case 0:
+		return r
+	case optionalFlusher:
+		return struct {
+			*responseWriter
+			flushWriter
+		}{r, f}
+	case optionalHijacker:
+		return struct {
+			*responseWriter
+			hijackWriter
+		}{r, h}
+	case optionalFlusher | optionalHijacker:
+		return struct {
+			*responseWriter
+			flushWriter
+			hijackWriter
+		}{r, f, h}
+	case optionalPusher:
+		return struct {
+			*responseWriter
+			pushWriter
+		}{r, p}
+	case optionalFlusher | optionalPusher:
+		return struct {
+			*responseWriter
+			flushWriter
+			pushWriter
+		}{r, f, p}
+	case optionalHijacker | optionalPusher:
+		return struct {
+			*responseWriter
+			hijackWriter
+			pushWriter
+		}{r, h, p}
+	case optionalFlusher | optionalHijacker | optionalPusher:
+		return struct {
+			*responseWriter
+			flushWriter
+			hijackWriter
+			pushWriter
+		}{r, f, h, p}
+	case optionalReaderFrom:
+		return struct {
+			*responseWriter
+			readFromWriter
+		}{r, rf}
+	case optionalFlusher | optionalReaderFrom:
+		return struct {
+			*responseWriter
+			flushWriter
+			readFromWriter
+		}{r, f, rf}
+	case optionalHijacker | optionalReaderFrom:
+		return struct {
+			*responseWriter
+			hijackWriter
+			readFromWriter
+		}{r, h, rf}
+	case optionalFlusher | optionalHijacker | optionalReaderFrom:
+		return struct {
+			*responseWriter
+			flushWriter
+			hijackWriter
+			readFromWriter
+		}{r, f, h, rf}
+	case optionalPusher | optionalReaderFrom:
+		return struct {
+			*responseWriter
+			pushWriter
+			readFromWriter
+		}{r, p, rf}
+	case optionalFlusher | optionalPusher | optionalReaderFrom:
+		return struct {
+			*responseWriter
+			flushWriter
+			pushWriter
+			readFromWriter
+		}{r, f, p, rf}
+	case optionalHijacker | optionalPusher | optionalReaderFrom:
+		return struct {
+			*responseWriter
+			hijackWriter
+			pushWriter
+			readFromWriter
+		}{r, h, p, rf}
+	default:
+		return struct {
+			*responseWriter
+			flushWriter
+			hijackWriter
+			pushWriter
+			readFromWriter
+		}{r, f, h, p, rf}
+	}
+}
+
+// flushWriter, hijackWriter, pushWriter and readFromWriter add the method of
+// an optional interface to a responseWriter.
+type (
+	flushWriter    struct{ *responseWriter }
+	hijackWriter   struct{ *responseWriter }
+	pushWriter     struct{ *responseWriter }
+	readFromWriter struct{ *responseWriter }
+)
+
+// Flush sends any buffered data to the client, writing the header first if
+// needed.
+func (w flushWriter) Flush() {
+	_ = w.flush()
+}
+
+// FlushError is like Flush, but returns the error of the underlying writer.
+// It is used by http.ResponseController.
+func (w flushWriter) FlushError() error {
+	return w.flush()
+}
+
+// Hijack lets the caller take over the connection, e.g. for WebSockets.
+func (w hijackWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return w.hijack()
+}
+
+// Push initiates an HTTP/2 server push.
+func (w pushWriter) Push(target string, opts *http.PushOptions) error {
+	return w.push(target, opts)
+}
+
+// ReadFrom copies src to the response body, writing the header first if
+// needed, so that files can be sent with sendfile on supported platforms.
+func (w readFromWriter) ReadFrom(src io.Reader) (int64, error) {
+	return w.readFrom(src)
 }
 
 // WriteHeader sets the HTTP status code for the response.
-// If called multiple times with different codes, a warning is logged.
-func (r *ResponseWriter) WriteHeader(status int) {
-	if r.wrote && r.status != status {
+// If called multiple times with different codes, a warning is logged, unless
+// the response is buffered. It does nothing once the connection has been
+// hijacked.
+func (r *responseWriter) WriteHeader(status int) {
+	if r.hijacked {
+		return
+	}
+	if r.wrote && r.status != status && r.buffer == nil {
 		log.Printf("[nexora] warning: status code overwritten from %d to %d", r.status, status)
 	}
 	r.status = status
-	r.ResponseWriter.WriteHeader(status)
 	r.wrote = true
+	if r.buffer == nil {
+		r.runBefore()
+		r.ResponseWriter.WriteHeader(status)
+	}
 }
 
 // Write writes the response body and automatically sets the status code to 200
 // if WriteHeader was not previously called.
-func (r *ResponseWriter) Write(b []byte) (int, error) {
+// http.ErrHijacked is returned once the connection has been hijacked.
+func (r *responseWriter) Write(b []byte) (int, error) {
+	if r.hijacked {
+		return 0, http.ErrHijacked
+	}
 	if !r.wrote {
 		r.WriteHeader(200)
 	}
+	if r.buffer != nil {
+		n, _ := r.buffer.Write(b)
+		r.size += n
+		return n, nil
+	}
 	n, err := r.ResponseWriter.Write(b)
 	r.size += n
 	return n, err
 }
 
+// readFrom copies src to the response body with the ReadFrom method of the
+// underlying writer, writing the header first if needed.
+func (r *responseWriter) readFrom(src io.Reader) (int64, error) {
+	if r.hijacked {
+		return 0, http.ErrHijacked
+	}
+	if !r.wrote {
+		r.WriteHeader(200)
+	}
+
+	if r.buffer != nil {
+		n, err := r.buffer.ReadFrom(src)
+		r.size += int(n)
+		return n, err
+	}
+
+	n, err := r.ResponseWriter.(io.ReaderFrom).ReadFrom(src)
+	r.size += int(n)
+	return n, err
+}
+
+// flush sends any buffered data to the client, writing the header first if
+// needed, and returns the error of the underlying writer.
+// http.ErrNotSupported is returned if the underlying writer cannot be flushed.
+// In buffered mode, the buffered response is sent and buffering is disabled
+// for the rest of the response.
+func (r *responseWriter) flush() error {
+	if r.hijacked {
+		return http.ErrHijacked
+	}
+	if err := r.commit(); err != nil {
+		return err
+	}
+
+	switch w := r.ResponseWriter.(type) {
+	case interface{ FlushError() error }:
+		if !r.wrote {
+			r.WriteHeader(r.status)
+		}
+		return w.FlushError()
+	case http.Flusher:
+		if !r.wrote {
+			r.WriteHeader(r.status)
+		}
+		w.Flush()
+		return nil
+	}
+
+	return http.ErrNotSupported
+}
+
+// hijack lets the caller take over the connection, e.g. for WebSockets.
+// http.ErrNotSupported is returned if the underlying writer cannot be hijacked.
+func (r *responseWriter) hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := r.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, http.ErrNotSupported
+	}
+
+	conn, rw, err := hijacker.Hijack()
+	if err == nil {
+		r.hijacked = true
+	}
+	return conn, rw, err
+}
+
+// push initiates an HTTP/2 server push.
+// http.ErrNotSupported is returned if the underlying writer does not support it.
+func (r *responseWriter) push(target string, opts *http.PushOptions) error {
+	if pusher, ok := r.ResponseWriter.(http.Pusher); ok {
+		return pusher.Push(target, opts)
+	}
+	return http.ErrNotSupported
+}
+
+// Unwrap returns the underlying http.ResponseWriter. It is used by
+// http.ResponseController to reach methods such as SetReadDeadline.
+func (r *responseWriter) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// Hijacked reports whether the connection has been hijacked.
+func (r *responseWriter) Hijacked() bool {
+	return r.hijacked
+}
+
+// Buffer enables buffered mode, in which the status and body are held in a
+// pooled buffer and only sent once the request is done. It does nothing if
+// the header has already been written.
+//
+// Buffered mode is usually enabled for a route or group with the
+// BufferResponse middleware.
+func (r *responseWriter) Buffer() {
+	if r.wrote || r.hijacked || r.buffer != nil {
+		return
+	}
+	r.buffer = bytebufferpool.Get()
+}
+
+// Buffered reports whether the response is buffered and not yet sent.
+func (r *responseWriter) Buffered() bool {
+	return r.buffer != nil
+}
+
+// Body returns the buffered response body, or nil if the response is not
+// buffered. The returned slice is only valid until the response is sent.
+func (r *responseWriter) Body() []byte {
+	if r.buffer == nil {
+		return nil
+	}
+	return r.buffer.B
+}
+
+// Reset discards the buffered body and status, so that a different response
+// can be written. The headers are kept. It reports whether the response could
+// be reset, which is only the case in buffered mode.
+func (r *responseWriter) Reset() bool {
+	if r.buffer == nil {
+		return false
+	}
+	r.buffer.Reset()
+	r.status = 200
+	r.size = 0
+	r.wrote = false
+	return true
+}
+
+// Before registers a function that is called right before the header is
+// sent, e.g. to add headers computed from the buffered body. Functions are
+// called in the order they were registered. Functions registered after the
+// header has been sent are never called.
+func (r *responseWriter) Before(fn func()) {
+	r.before = append(r.before, fn)
+}
+
+// After registers a function that is called once the response is complete.
+// Functions are called in the order they were registered.
+func (r *responseWriter) After(fn func()) {
+	r.after = append(r.after, fn)
+}
+
+// runBefore calls the Before functions once.
+func (r *responseWriter) runBefore() {
+	hooks := r.before
+	r.before = nil
+	for _, fn := range hooks {
+		fn()
+	}
+}
+
+// commit sends the buffered status and body and disables buffered mode.
+func (r *responseWriter) commit() error {
+	if r.buffer == nil {
+		return nil
+	}
+
+	r.runBefore()
+
+	buffer := r.buffer
+	r.buffer = nil
+	defer bytebufferpool.Put(buffer)
+
+	// The body may have been changed since Content-Length was set.
+	if bodyAllowed(r.status) {
+		r.Header().Set(HeaderContentLength, strconv.Itoa(len(buffer.B)))
+	}
+	r.ResponseWriter.WriteHeader(r.status)
+	if len(buffer.B) == 0 || !bodyAllowed(r.status) {
+		return nil
+	}
+	_, err := r.ResponseWriter.Write(buffer.B)
+	return err
+}
+
+// finish completes the response once the request is done: the buffered
+// response is sent, or the header if nothing was written yet but Before
+// functions are pending, and the After functions are called.
+func (r *responseWriter) finish() {
+	if !r.hijacked {
+		if r.buffer != nil {
+			if err := r.commit(); err != nil {
+				log.Printf("[nexora] failed to write buffered response: %v", err)
+			}
+		} else if !r.wrote && len(r.before) > 0 {
+			r.WriteHeader(r.status)
+		}
+	}
+	if r.buffer != nil {
+		bytebufferpool.Put(r.buffer)
+		r.buffer = nil
+	}
+
+	hooks := r.after
+	r.after = nil
+	for _, fn := range hooks {
+		fn()
+	}
+}
+
+// bodyAllowed reports whether a response with the given status may have a body.
+func bodyAllowed(status int) bool {
+	return status >= 200 && status != StatusNoContent && status != StatusNotModified
+}
+
+// BufferResponse is a middleware that enables buffered mode for the response
+// of the routes it is applied to, so that handlers further up the chain can
+// change the status, headers and body after the route handler returns.
+//
+// Example:
+//
+//	api := app.Group("/api", nexora.BufferResponse, etagMiddleware)
+func BufferResponse(c *Context) error {
+	c.writer.Buffer()
+	return c.Next()
+}
+
 // Size returns the total number of bytes written to the response body.
-func (r *ResponseWriter) Size() int {
+func (r *responseWriter) Size() int {
 	return r.size
 }
 
 // Status returns the HTTP status code of the response.
-func (r *ResponseWriter) Status() int {
+func (r *responseWriter) Status() int {
 	return r.status
 }
@@ -1,12 +1,18 @@
 package nexora
 
 import (
+	"bufio"
 	"bytes"
+	"errors"
+	"io"
 	"log"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestResponseWriter_Basic(t *testing.T) {
@@ -51,3 +57,383 @@ func TestResponseWriter_OverwriteStatus(t *testing.T) {
 		t.Errorf("expected warning log for overwritten status code, got: %q", logOutput)
 	}
 }
+
+func TestResponseWriter_Flush(t *testing.T) {
+	rr := httptest.NewRecorder()
+	w := NewResponseWriter(rr)
+
+	wrote := false
+	w.Before(func() { wrote = true })
+	w.(http.Flusher).Flush()
+
+	if !rr.Flushed {
+		t.Error("expected underlying recorder to be flushed")
+	}
+	if rr.Code != http.StatusOK || !wrote {
+		t.Errorf("expected header to be written with 200, got %d", rr.Code)
+	}
+}
+
+// fakeWriter is a plain http.ResponseWriter which records the calls to the
+// optional interfaces implemented by the types returned by newFakeWriter.
+type fakeWriter struct {
+	http.ResponseWriter
+	flushed, hijacked, pushed, readFrom bool
+}
+
+type (
+	fakeFlusher    struct{ *fakeWriter }
+	fakeHijacker   struct{ *fakeWriter }
+	fakePusher     struct{ *fakeWriter }
+	fakeReaderFrom struct{ *fakeWriter }
+)
+
+func (f fakeFlusher) Flush() { f.flushed = true }
+
+func (f fakeHijacker) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	f.hijacked = true
+	return nil, nil, nil
+}
+
+func (f fakePusher) Push(string, *http.PushOptions) error {
+	f.pushed = true
+	return nil
+}
+
+func (f fakeReaderFrom) ReadFrom(src io.Reader) (int64, error) {
+	f.readFrom = true
+	return io.Copy(f.ResponseWriter, src)
+}
+
+const (
+	supportsFlush = 1 << iota
+	supportsHijack
+	supportsPush
+	supportsReadFrom
+)
+
+// newFakeWriter returns a writer implementing exactly the optional
+// interfaces selected by mask.
+func newFakeWriter(mask int) (http.ResponseWriter, *fakeWriter) {
+	f := &fakeWriter{ResponseWriter: httptest.NewRecorder()}
+	fl, hj, pu, rf := fakeFlusher{f}, fakeHijacker{f}, fakePusher{f}, fakeReaderFrom{f}
+
+	switch mask {
+	case 0:
+		return struct{ http.ResponseWriter }{f}, f
+	case supportsFlush:
+		return struct {
+			http.ResponseWriter
+			http.Flusher
+		}{f, fl}, f
+	case supportsHijack:
+		return struct {
+			http.ResponseWriter
+			http.Hijacker
+		}{f, hj}, f
+	case supportsFlush | supportsHijack:
+		return struct {
+			http.ResponseWriter
+			http.Flusher
+			http.Hijacker
+		}{f, fl, hj}, f
+	case supportsPush:
+		return struct {
+			http.ResponseWriter
+			http.Pusher
+		}{f, pu}, f
+	case supportsFlush | supportsPush:
+		return struct {
+			http.ResponseWriter
+			http.Flusher
+			http.Pusher
+		}{f, fl, pu}, f
+	case supportsHijack | supportsPush:
+		return struct {
+			http.ResponseWriter
+			http.Hijacker
+			http.Pusher
+		}{f, hj, pu}, f
+	case supportsFlush | supportsHijack | supportsPush:
+		return struct {
+			http.ResponseWriter
+			http.Flusher
+			http.Hijacker
+			http.Pusher
+		}{f, fl, hj, pu}, f
+	case supportsReadFrom:
+		return struct {
+			http.ResponseWriter
+			io.ReaderFrom
+		}{f, rf}, f
+	case supportsFlush | supportsReadFrom:
+		return struct {
+			http.ResponseWriter
+			http.Flusher
+			io.ReaderFrom
+		}{f, fl, rf}, f
+	case supportsHijack | supportsReadFrom:
+		return struct {
+			http.ResponseWriter
+			http.Hijacker
+			io.ReaderFrom
+		}{f, hj, rf}, f
+	case supportsFlush | supportsHijack | supportsReadFrom:
+		return struct {
+			http.ResponseWriter
+			http.Flusher
+			http.Hijacker
+			io.ReaderFrom
+		}{f, fl, hj, rf}, f
+	case supportsPush | supportsReadFrom:
+		return struct {
+			http.ResponseWriter
+			http.Pusher
+			io.ReaderFrom
+		}{f, pu, rf}, f
+	case supportsFlush | supportsPush | supportsReadFrom:
+		return struct {
+			http.ResponseWriter
+			http.Flusher
+			http.Pusher
+			io.ReaderFrom
+		}{f, fl, pu, rf}, f
+	case supportsHijack | supportsPush | supportsReadFrom:
+		return struct {
+			http.ResponseWriter
+			http.Hijacker
+			http.Pusher
+			io.ReaderFrom
+		}{f, hj, pu, rf}, f
+	default:
+		return struct {
+			http.ResponseWriter
+			http.Flusher
+			http.Hijacker
+			http.Pusher
+			io.ReaderFrom
+		}{f, fl, hj, pu, rf}, f
+	}
+}
+
+func TestResponseWriter_OptionalInterfaces(t *testing.T) {
+	for mask := 0; mask < 16; mask++ {
+		underlying, fake := newFakeWriter(mask)
+		w := NewResponseWriter(underlying)
+		rc := http.NewResponseController(w)
+
+		// Flush
+		flusher, ok := w.(http.Flusher)
+		if ok != (mask&supportsFlush != 0) {
+			t.Errorf("mask %04b: implements http.Flusher = %v", mask, ok)
+		}
+		err := rc.Flush()
+		if mask&supportsFlush != 0 {
+			if err != nil || !fake.flushed {
+				t.Errorf("mask %04b: Flush() = %v, flushed = %v", mask, err, fake.flushed)
+			}
+			fake.flushed = false
+			if flusher.Flush(); !fake.flushed {
+				t.Errorf("mask %04b: Flush() did not flush the underlying writer", mask)
+			}
+		} else if !errors.Is(err, http.ErrNotSupported) {
+			t.Errorf("mask %04b: Flush() = %v, want ErrNotSupported", mask, err)
+		}
+
+		// Push
+		pusher, ok := w.(http.Pusher)
+		if ok != (mask&supportsPush != 0) {
+			t.Errorf("mask %04b: implements http.Pusher = %v", mask, ok)
+		}
+		if ok {
+			if err := pusher.Push("/style.css", nil); err != nil || !fake.pushed {
+				t.Errorf("mask %04b: Push() = %v, pushed = %v", mask, err, fake.pushed)
+			}
+		}
+
+		// ReadFrom, which io.Copy uses if it is implemented
+		if _, ok := w.(io.ReaderFrom); ok != (mask&supportsReadFrom != 0) {
+			t.Errorf("mask %04b: implements io.ReaderFrom = %v", mask, ok)
+		}
+		n, err := io.Copy(w, struct{ io.Reader }{strings.NewReader("hello")})
+		if err != nil || n != 5 || w.Size() != 5 {
+			t.Errorf("mask %04b: io.Copy() = %d, %v, size = %d", mask, n, err, w.Size())
+		}
+		if fake.readFrom != (mask&supportsReadFrom != 0) {
+			t.Errorf("mask %04b: underlying ReadFrom used = %v", mask, fake.readFrom)
+		}
+
+		// Hijack
+		if _, ok := w.(http.Hijacker); ok != (mask&supportsHijack != 0) {
+			t.Errorf("mask %04b: implements http.Hijacker = %v", mask, ok)
+		}
+		_, _, err = rc.Hijack()
+		if mask&supportsHijack != 0 {
+			if err != nil || !fake.hijacked || !w.Hijacked() {
+				t.Errorf("mask %04b: Hijack() = %v, hijacked = %v", mask, err, fake.hijacked)
+			}
+			if _, err := w.Write([]byte("x")); err != http.ErrHijacked {
+				t.Errorf("mask %04b: Write() after Hijack = %v, want ErrHijacked", mask, err)
+			}
+		} else if !errors.Is(err, http.ErrNotSupported) || w.Hijacked() {
+			t.Errorf("mask %04b: Hijack() = %v, want ErrNotSupported", mask, err)
+		}
+
+		if w.Unwrap() != underlying {
+			t.Errorf("mask %04b: Unwrap() did not return the underlying writer", mask)
+		}
+	}
+}
+
+func TestResponseWriter_ResponseController(t *testing.T) {
+	rr := httptest.NewRecorder()
+	w := NewResponseWriter(rr)
+
+	rc := http.NewResponseController(w)
+	if err := rc.Flush(); err != nil {
+		t.Fatalf("Flush() = %v", err)
+	}
+	if !rr.Flushed {
+		t.Error("expected underlying recorder to be flushed")
+	}
+
+	// The recorder has no deadlines, which must be reported through Unwrap
+	if err := rc.SetWriteDeadline(time.Now()); !errors.Is(err, http.ErrNotSupported) {
+		t.Errorf("SetWriteDeadline() = %v, want ErrNotSupported", err)
+	}
+}
+
+func TestResponseWriter_Buffered(t *testing.T) {
+	app := New()
+
+	timing := func(c *Context) error {
+		w := c.ResponseWriter()
+		w.Before(func() { w.Header().Set("X-Body-Size", strconv.Itoa(len(w.Body()))) })
+		if err := c.Next(); err != nil {
+			return err
+		}
+		// The handler has written, but the response can still be changed
+		w.Header().Set("X-Seen", "yes")
+		w.WriteHeader(http.StatusAccepted)
+		return nil
+	}
+
+	app.Get("/buffered", BufferResponse, timing, func(c *Context) error {
+		return c.SendString("hello")
+	})
+
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/buffered", nil))
+
+	if rec.Code != http.StatusAccepted {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusAccepted)
+	}
+	if rec.Body.String() != "hello" {
+		t.Errorf("body = %q, want %q", rec.Body.String(), "hello")
+	}
+	if rec.Header().Get("X-Seen") != "yes" || rec.Header().Get("X-Body-Size") != "5" {
+		t.Errorf("headers = %v", rec.Header())
+	}
+	if rec.Header().Get(HeaderContentLength) != "5" {
+		t.Errorf("Content-Length = %q, want %q", rec.Header().Get(HeaderContentLength), "5")
+	}
+}
+
+func TestResponseWriter_BufferedError(t *testing.T) {
+	app := New()
+	app.Get("/fail", BufferResponse, func(c *Context) error {
+		c.ResponseWriter().Write([]byte("partial output"))
+		return ErrConflict
+	})
+
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/fail", nil))
+
+	if rec.Code != http.StatusConflict {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusConflict)
+	}
+	if rec.Body.String() != "Conflict\n" {
+		t.Errorf("body = %q, want %q", rec.Body.String(), "Conflict\n")
+	}
+}
+
+func TestResponseWriter_BufferedRewrite(t *testing.T) {
+	rr := httptest.NewRecorder()
+	w := &responseWriter{ResponseWriter: rr, status: 200}
+	w.Buffer()
+
+	w.WriteHeader(http.StatusNotFound)
+	w.Write([]byte("plain not found"))
+
+	if rr.Code != http.StatusOK || rr.Body.Len() != 0 {
+		t.Fatal("buffered response was written before finish")
+	}
+
+	if !w.Reset() {
+		t.Fatal("Reset() = false for a buffered response")
+	}
+	w.WriteHeader(http.StatusNotFound)
+	w.Write([]byte(`{"error":"not found"}`))
+	w.finish()
+
+	if rr.Code != http.StatusNotFound || rr.Body.String() != `{"error":"not found"}` {
+		t.Errorf("got %d %q", rr.Code, rr.Body.String())
+	}
+	if w.Buffered() {
+		t.Error("Buffered() = true after finish")
+	}
+}
+
+func TestResponseWriter_BufferedFlush(t *testing.T) {
+	rr := httptest.NewRecorder()
+	w := NewResponseWriter(rr)
+	w.Buffer()
+
+	w.Write([]byte("a"))
+	w.(http.Flusher).Flush()
+	if rr.Body.String() != "a" || !rr.Flushed {
+		t.Fatalf("Flush() did not send the buffered body, got %q", rr.Body.String())
+	}
+
+	// After a flush the response is streamed
+	w.Write([]byte("b"))
+	if rr.Body.String() != "ab" || w.Buffered() {
+		t.Errorf("body = %q, buffered = %v", rr.Body.String(), w.Buffered())
+	}
+}
+
+func TestResponseWriter_Hooks(t *testing.T) {
+	var calls []string
+
+	app := New()
+	app.Use(func(c *Context) error {
+		w := c.ResponseWriter()
+		w.Before(func() {
+			calls = append(calls, "before")
+			w.Header().Set("X-Before", "1")
+		})
+		w.After(func() { calls = append(calls, "after") })
+		return c.Next()
+	})
+	app.Get("/write", func(c *Context) error {
+		calls = append(calls, "handler")
+		return c.SendString("ok")
+	})
+	app.Get("/empty", func(c *Context) error {
+		calls = append(calls, "handler")
+		return nil
+	})
+
+	for _, path := range []string{"/write", "/empty"} {
+		calls = nil
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+
+		if strings.Join(calls, ",") != "handler,before,after" {
+			t.Errorf("%s: calls = %v", path, calls)
+		}
+		if rec.Header().Get("X-Before") != "1" {
+			t.Errorf("%s: Before hook did not run before the header was sent", path)
+		}
+	}
+}
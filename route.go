@@ -8,11 +8,15 @@ import (
 
 // Route represents a single route in the routing tree.
 type Route struct {
-	group          *RouteGroup // The group this route belongs to, which contains shared settings and handlers.
-	method, path   string      // The HTTP method (GET, POST, etc.) and the path for this route.
-	name, template string      // The name of the route and a template for generating URLs.
-	tags           []any       // Custom data associated with the route, which can be used for various purposes.
-	routes         []*Route    // Nested routes, which can be used to create more complex routing structures.
+	group          *RouteGroup      // The group this route belongs to, which contains shared settings and handlers.
+	method, path   string           // The HTTP method (GET, POST, etc.) and the path for this route.
+	name, template string           // The name of the route and a template for generating URLs.
+	tags           []any            // Custom data associated with the route, which can be used for various purposes.
+	routes         []*Route         // Nested routes, which can be used to create more complex routing structures.
+	handlers       []Handler        // The handlers of the route, including the group handlers.
+	matchers       []routeMatcher   // Conditions on the request, besides method and path.
+	source         string           // File and line where the route was registered.
+	conflicts      []*RouteConflict // Paths of the route shadowed by routes registered before it.
 }
 
 // Name sets the name of the route.
@@ -49,6 +53,14 @@ func (r *Route) Path() string {
 	return r.group.prefix + r.path
 }
 
+// host returns the host pattern the route is restricted to, if any.
+func (r *Route) host() string {
+	if r.group.host == nil {
+		return ""
+	}
+	return r.group.host.pattern
+}
+
 // Tags returns all custom data associated with the route.
 func (r *Route) Tags() []any {
 	return r.tags
@@ -108,19 +120,109 @@ func (r *Route) Handle(method string, handlers ...Handler) *Route {
 // The parameters should be given in the sequence of name1, value1, name2, value2, and so on.
 // If a parameter in the route is not provided a value, the parameter token will remain in the resulting URL.
 // The method will perform URL encoding for all given parameter values.
+// Use BuildURL to validate the parameters instead.
 func (r *Route) URL(pairs ...any) (s string) {
 	s = r.template
 	for i := range pairs {
 		name := fmt.Sprintf("{%v}", pairs[i])
 		value := ""
 		if i < len(pairs)-1 {
-			value = url.QueryEscape(fmt.Sprint(pairs[i+1]))
+			value = url.PathEscape(fmt.Sprint(pairs[i+1]))
 		}
 		s = strings.ReplaceAll(s, name, value)
 	}
 	return
 }
 
+// BuildURL creates a URL using the current route and the given parameters,
+// given in the sequence of name1, value1, name2, value2, and so on.
+//
+// Unlike URL, it returns an error if a required parameter is missing or
+// empty, or if a value does not match the constraint of its parameter.
+// Values are path escaped, except for the slashes of wildcard parameters.
+// A missing optional parameter is left out together with the rest of the
+// path, like the paths registered for the route. Pairs whose name is not a
+// parameter of the route are added as query string.
+//
+// Example:
+//
+//	app.Get("/users/{id:int}/files/{path:*}", handler).Name("file")
+//
+//	app.Route("file").BuildURL("id", 42, "path", "docs/a b.txt", "v", 2)
+//	// "/users/42/files/docs/a%20b.txt?v=2"
+func (r *Route) BuildURL(pairs ...any) (string, error) {
+	if len(pairs)%2 != 0 {
+		return "", fmt.Errorf("nexora: odd number of parameters to build the URL of route %s", r)
+	}
+
+	values := make(map[string]string, len(pairs)/2)
+	names := make([]string, 0, len(pairs)/2)
+	for i := 0; i < len(pairs); i += 2 {
+		name := fmt.Sprint(pairs[i])
+		if _, ok := values[name]; !ok {
+			names = append(names, name)
+		}
+		values[name] = fmt.Sprint(pairs[i+1])
+	}
+
+	path := r.Path()
+	var b strings.Builder
+
+	for start := 0; start < len(path); start++ {
+		if path[start] != '{' {
+			b.WriteByte(path[start])
+			continue
+		}
+
+		end := paramEnd(path, start)
+		param := routeParams(path[start : end+1])[0]
+		start = end
+
+		value, ok := values[param.Name]
+		delete(values, param.Name)
+
+		switch {
+		case (!ok || value == "") && param.Optional:
+			// Stop at the path registered without the optional parameter
+			s := strings.TrimSuffix(b.String(), "/")
+			if s == "" {
+				s = "/"
+			}
+			b.Reset()
+			b.WriteString(s)
+			start = len(path)
+		case !ok:
+			return "", fmt.Errorf("nexora: missing parameter %q to build the URL of route %s", param.Name, r)
+		case value == "" && !param.Wildcard:
+			return "", fmt.Errorf("nexora: empty parameter %q to build the URL of route %s", param.Name, r)
+		case param.Wildcard:
+			segments := strings.Split(strings.TrimPrefix(value, "/"), "/")
+			for i := range segments {
+				segments[i] = url.PathEscape(segments[i])
+			}
+			b.WriteString(strings.Join(segments, "/"))
+		default:
+			if param.Constraint != "" && !matchConstraint(r.group.nexora.resolveConstraint, param.Constraint, value) {
+				return "", fmt.Errorf("nexora: parameter %q of route %s must match %s, got %q", param.Name, r, param.Constraint, value)
+			}
+			b.WriteString(url.PathEscape(value))
+		}
+	}
+
+	query := url.Values{}
+	for _, name := range names {
+		if value, ok := values[name]; ok {
+			query.Set(name, value)
+		}
+	}
+	if len(query) > 0 {
+		b.WriteByte('?')
+		b.WriteString(query.Encode())
+	}
+
+	return b.String(), nil
+}
+
 // String returns the string representation of the route.
 func (r *Route) String() string {
 	return r.method + " " + r.group.prefix + r.path
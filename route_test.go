@@ -94,3 +94,72 @@ func TestRoute_NestedTag(t *testing.T) {
 		}
 	}
 }
+
+func TestRoute_URLPrefix(t *testing.T) {
+	app := New()
+	route := app.Group("/api").Get("/files/{name}", func(c *Context) error { return nil })
+
+	if url := route.URL("name", "a b"); url != "/api/files/a%20b" {
+		t.Errorf("URL() = %q, want %q", url, "/api/files/a%20b")
+	}
+}
+
+func TestRoute_BuildURL(t *testing.T) {
+	app := New()
+	api := app.Group("/api")
+	handler := func(c *Context) error { return nil }
+	user := api.Get("/users/{id:int}", handler)
+	files := api.Get("/users/{id:int}/files/{path:*}", handler)
+	posts := api.Get("/posts/{slug:[a-z-]+}/{page?}", handler)
+	ranged := api.Get("/ages/{age:range(18,20)}", handler)
+
+	tests := []struct {
+		route *Route
+		pairs []any
+		want  string
+	}{
+		{user, []any{"id", 42}, "/api/users/42"},
+		{user, []any{"id", -1, "sort", "name desc", "page", 2}, "/api/users/-1?page=2&sort=name+desc"},
+		{files, []any{"id", 1, "path", "docs/a b.txt"}, "/api/users/1/files/docs/a%20b.txt"},
+		{files, []any{"id", 1, "path", ""}, "/api/users/1/files/"},
+		{posts, []any{"slug", "hello-world", "page", 3}, "/api/posts/hello-world/3"},
+		{posts, []any{"slug", "hello-world"}, "/api/posts/hello-world"},
+		{ranged, []any{"age", 19}, "/api/ages/19"},
+	}
+	for _, tt := range tests {
+		got, err := tt.route.BuildURL(tt.pairs...)
+		if err != nil || got != tt.want {
+			t.Errorf("%s BuildURL(%v) = %q, %v, want %q", tt.route, tt.pairs, got, err, tt.want)
+		}
+	}
+
+	errTests := []struct {
+		route *Route
+		pairs []any
+	}{
+		{user, []any{}},
+		{user, []any{"id"}},
+		{user, []any{"id", "abc"}},
+		{user, []any{"id", ""}},
+		{posts, []any{"slug", "Hello"}},
+		{ranged, []any{"age", 21}},
+	}
+	for _, tt := range errTests {
+		if got, err := tt.route.BuildURL(tt.pairs...); err == nil {
+			t.Errorf("%s BuildURL(%v) = %q, want error", tt.route, tt.pairs, got)
+		}
+	}
+}
+
+func TestContext_URLFor(t *testing.T) {
+	app := New()
+	app.Get("/users/{id:int}", func(c *Context) error { return nil }).Name("user")
+
+	c := newContext(app)
+	if url, err := c.URLFor("user", "id", 7); err != nil || url != "/users/7" {
+		t.Errorf("URLFor() = %q, %v", url, err)
+	}
+	if _, err := c.URLFor("missing"); err == nil {
+		t.Error("expected error for unknown route")
+	}
+}
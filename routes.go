@@ -0,0 +1,157 @@
+package nexora
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"runtime"
+	"strings"
+	"text/tabwriter"
+)
+
+// RouteInfo describes a registered route.
+type RouteInfo struct {
+	Method   string      `json:"method"`
+	Host     string      `json:"host,omitempty"` // Host pattern the route is restricted to, see Nexora.Host
+	Path     string      `json:"path"`           // Full path as registered, including the group prefix
+	Name     string      `json:"name,omitempty"`
+	Tags     []any       `json:"tags,omitempty"`
+	Handlers []string    `json:"handlers"` // Function names of the handlers, including the group handlers
+	Params   []ParamInfo `json:"params,omitempty"`
+
+	// Paths the route is registered under when it has optional parameters,
+	// e.g. "/users" and "/users/{id}" for "/users/{id?}".
+	Expansions []string `json:"expansions,omitempty"`
+}
+
+// ParamInfo describes a parameter of a route path.
+type ParamInfo struct {
+	Name string `json:"name"`
+
+	// Constraint as written in the path, e.g. "int", "range(18,120)" or a
+	// regular expression. It is empty if the parameter is unconstrained.
+	Constraint string `json:"constraint,omitempty"`
+
+	Optional bool `json:"optional,omitempty"`
+	Wildcard bool `json:"wildcard,omitempty"`
+}
+
+// Routes returns all registered routes, in the order they were registered.
+//
+// Example:
+//
+//	for _, route := range app.Routes() {
+//		fmt.Println(route.Method, route.Path)
+//	}
+func (n *Nexora) Routes() []RouteInfo {
+	routes := make([]RouteInfo, 0, len(n.routes))
+	for _, r := range n.routes {
+		path := r.Path()
+
+		info := RouteInfo{
+			Method:   r.method,
+			Host:     r.host(),
+			Path:     path,
+			Name:     r.name,
+			Tags:     r.tags,
+			Handlers: make([]string, len(r.handlers)),
+			Params:   routeParams(path),
+		}
+		for i, h := range r.handlers {
+			info.Handlers[i] = handlerName(h)
+		}
+		if paths := getOptionalPaths(path); len(paths) > 0 {
+			info.Expansions = paths
+		}
+
+		routes = append(routes, info)
+	}
+	return routes
+}
+
+// WriteRoutes writes a table of all registered routes to w. The paths under
+// which a route with optional parameters is registered are listed below it,
+// and the paths of routes restricted to a host are prefixed with the host.
+//
+// Example:
+//
+//	app.WriteRoutes(os.Stdout)
+//
+// Output:
+//
+//	METHOD  PATH           NAME  HANDLERS
+//	GET     /users/{id?}   user  main.auth, main.getUser
+//	          /users
+//	          /users/{id}
+func (n *Nexora) WriteRoutes(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "METHOD\tPATH\tNAME\tHANDLERS")
+
+	for _, route := range n.Routes() {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", route.Method, route.Host+route.Path, route.Name, strings.Join(route.Handlers, ", "))
+		for _, path := range route.Expansions {
+			fmt.Fprintf(tw, "\t  %s\t\t\n", route.Host+path)
+		}
+	}
+
+	return tw.Flush()
+}
+
+// WriteRoutesJSON writes all registered routes to w as a JSON array.
+func (n *Nexora) WriteRoutesJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(n.Routes())
+}
+
+// handlerName returns the function name of the handler.
+func handlerName(h Handler) string {
+	if fn := runtime.FuncForPC(reflect.ValueOf(h).Pointer()); fn != nil {
+		return fn.Name()
+	}
+	return "unknown"
+}
+
+// routeParams returns the parameters of the route path.
+func routeParams(path string) []ParamInfo {
+	var params []ParamInfo
+
+	for start := 0; start < len(path); start++ {
+		if path[start] != '{' {
+			continue
+		}
+
+		end := paramEnd(path, start)
+		name, constraint, _ := strings.Cut(path[start+1:end], ":")
+		param := ParamInfo{Name: name, Constraint: constraint}
+		if constraint == "" && strings.HasSuffix(name, "?") {
+			param.Name, param.Optional = name[:len(name)-1], true
+		}
+		if constraint == "*" {
+			param.Constraint, param.Wildcard = "", true
+		}
+
+		params = append(params, param)
+		start = end
+	}
+
+	return params
+}
+
+// paramEnd returns the index of the bracket closing the parameter starting
+// at path[start], skipping the brackets of regular expressions.
+func paramEnd(path string, start int) int {
+	end, depth := start+1, 0
+	for ; end < len(path); end++ {
+		if path[end] == '{' {
+			depth++
+		} else if path[end] == '}' {
+			if depth == 0 {
+				break
+			}
+			depth--
+		}
+	}
+	return end
+}
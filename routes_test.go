@@ -0,0 +1,87 @@
+package nexora
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func routesTestAuth(c *Context) error { return c.Next() }
+
+func routesTestHandler(c *Context) error { return nil }
+
+func TestNexora_Routes(t *testing.T) {
+	app := New()
+	app.Get("/", routesTestHandler)
+	api := app.Group("/api", routesTestAuth)
+	api.Get("/users/{id?}", routesTestHandler).Name("user").Tag("users")
+	api.Post("/files/{name:[a-z]+}/{age:range(18,120)}", routesTestHandler)
+	app.Handle("PURGE", "/cache/{path:*}", routesTestHandler)
+
+	routes := app.Routes()
+	if len(routes) != 4 {
+		t.Fatalf("got %d routes, want 4", len(routes))
+	}
+
+	want := RouteInfo{
+		Method:     MethodGet,
+		Path:       "/api/users/{id?}",
+		Name:       "user",
+		Tags:       []any{"users"},
+		Handlers:   []string{"github.com/Abhishek2010dev/nexora.routesTestAuth", "github.com/Abhishek2010dev/nexora.routesTestHandler"},
+		Params:     []ParamInfo{{Name: "id", Optional: true}},
+		Expansions: []string{"/api/users", "/api/users/{id}"},
+	}
+	if !reflect.DeepEqual(routes[1], want) {
+		t.Errorf("route = %+v, want %+v", routes[1], want)
+	}
+
+	wantParams := []ParamInfo{{Name: "name", Constraint: "[a-z]+"}, {Name: "age", Constraint: "range(18,120)"}}
+	if !reflect.DeepEqual(routes[2].Params, wantParams) {
+		t.Errorf("params = %+v, want %+v", routes[2].Params, wantParams)
+	}
+
+	if r := routes[3]; r.Method != "PURGE" || r.Path != "/cache/{path:*}" || !reflect.DeepEqual(r.Params, []ParamInfo{{Name: "path", Wildcard: true}}) {
+		t.Errorf("route = %+v", r)
+	}
+}
+
+func TestNexora_WriteRoutes(t *testing.T) {
+	app := New()
+	app.Get("/users/{id?}", routesTestHandler).Name("user")
+	app.Post("/users", routesTestHandler)
+
+	var buf bytes.Buffer
+	if err := app.WriteRoutes(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	want := []string{
+		"METHOD  PATH           NAME  HANDLERS",
+		"GET     /users/{id?}   user  github.com/Abhishek2010dev/nexora.routesTestHandler",
+		"          /users",
+		"          /users/{id}",
+		"POST    /users               github.com/Abhishek2010dev/nexora.routesTestHandler",
+	}
+	for i := range lines {
+		lines[i] = strings.TrimRight(lines[i], " ")
+	}
+	if !reflect.DeepEqual(lines, want) {
+		t.Errorf("table =\n%s\nwant\n%s", strings.Join(lines, "\n"), strings.Join(want, "\n"))
+	}
+
+	buf.Reset()
+	if err := app.WriteRoutesJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var routes []RouteInfo
+	if err := json.Unmarshal(buf.Bytes(), &routes); err != nil {
+		t.Fatal(err)
+	}
+	if len(routes) != 2 || routes[0].Name != "user" || len(routes[0].Expansions) != 2 {
+		t.Errorf("JSON routes = %+v", routes)
+	}
+}
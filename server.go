@@ -0,0 +1,376 @@
+package nexora
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+)
+
+const (
+	// DefaultReadHeaderTimeout is the ReadHeaderTimeout set by New.
+	DefaultReadHeaderTimeout = 10 * time.Second
+
+	// DefaultIdleTimeout is the IdleTimeout set by New.
+	DefaultIdleTimeout = 120 * time.Second
+
+	// DefaultShutdownTimeout is the time given to active requests to complete
+	// when the server is shut down on a signal.
+	DefaultShutdownTimeout = 10 * time.Second
+)
+
+// ErrServerRunning is returned by the Run methods if the server is already running.
+var ErrServerRunning = errors.New("nexora: server is already running")
+
+// lifecycle holds the hooks and the state of the server started by Run.
+type lifecycle struct {
+	mu          sync.Mutex
+	onStart     []func() error
+	onListen    []func(addr net.Addr) error
+	onShutdown  []func(ctx context.Context) error
+	servers     []*http.Server
+	done        chan struct{} // Closed once Shutdown has completed
+	shutdownErr error
+}
+
+// OnStart registers a function that is called by Run before the server
+// starts listening. If it returns an error, the server is not started and Run
+// returns the error.
+func (n *Nexora) OnStart(fn func() error) {
+	n.lifecycle.mu.Lock()
+	defer n.lifecycle.mu.Unlock()
+	n.lifecycle.onStart = append(n.lifecycle.onStart, fn)
+}
+
+// OnListen registers a function that is called with the address the server
+// listens on, right before it starts accepting connections. If it returns an
+// error, the server is not started and Run returns the error.
+func (n *Nexora) OnListen(fn func(addr net.Addr) error) {
+	n.lifecycle.mu.Lock()
+	defer n.lifecycle.mu.Unlock()
+	n.lifecycle.onListen = append(n.lifecycle.onListen, fn)
+}
+
+// OnShutdown registers a function that is called by Shutdown once active
+// requests have completed, e.g. to flush metrics or close database pools.
+// Functions are called in the reverse order of their registration.
+func (n *Nexora) OnShutdown(fn func(ctx context.Context) error) {
+	n.lifecycle.mu.Lock()
+	defer n.lifecycle.mu.Unlock()
+	n.lifecycle.onShutdown = append(n.lifecycle.onShutdown, fn)
+}
+
+// Run starts the HTTP server on the specified address.
+// It uses the ServeHTTP method to handle incoming requests.
+// The address should be in the format "host:port", e.g., ":8080" or "localhost:8080".
+// If the address is empty, it defaults to ":8080".
+//
+// Run blocks until the server fails or is shut down. After a graceful
+// shutdown with Shutdown, or on a signal if HandleSignals is enabled, it waits
+// for the shutdown to complete and returns nil, or the error of the shutdown.
+//
+// Example:
+//
+//	app.HandleSignals = true
+//	app.OnShutdown(func(ctx context.Context) error { return db.Close() })
+//	log.Fatal(app.Run(":8080"))
+func (n *Nexora) Run(addr string) error {
+	if addr == "" {
+		addr = ":8080"
+	}
+	return n.run(func() (net.Listener, error) {
+		return net.Listen("tcp", addr)
+	}, nil)
+}
+
+// RunTLS starts an HTTPS server on the specified address, using the
+// certificate and key in the given PEM files. HTTP/2 is enabled.
+// If the address is empty, it defaults to ":8443".
+//
+// The files are checked for changes at most once per second, and the
+// certificate is reloaded when they change, so renewed certificates are
+// picked up without a restart. If a reload fails, the previous certificate
+// keeps being used.
+//
+// If RedirectHTTPAddr is set, plain HTTP requests on that address are
+// redirected to HTTPS. Otherwise RunTLS behaves like Run.
+//
+// Example:
+//
+//	app.RedirectHTTPAddr = ":80"
+//	log.Fatal(app.RunTLS(":443", "/etc/tls/tls.crt", "/etc/tls/tls.key"))
+func (n *Nexora) RunTLS(addr, certFile, keyFile string) error {
+	if addr == "" {
+		addr = ":8443"
+	}
+
+	certs, err := newCertReloader(certFile, keyFile)
+	if err != nil {
+		return err
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: certs.GetCertificate,
+	}
+
+	return n.run(func() (net.Listener, error) {
+		return net.Listen("tcp", addr)
+	}, tlsConfig)
+}
+
+// RunListener serves HTTP on the given listener, e.g. one passed by systemd
+// socket activation. Otherwise it behaves like Run.
+func (n *Nexora) RunListener(ln net.Listener) error {
+	return n.run(func() (net.Listener, error) {
+		return ln, nil
+	}, nil)
+}
+
+// RunUnix serves HTTP on a Unix domain socket at the given path. A stale
+// socket left behind by a previous process is removed first, and the socket
+// is removed again when the server stops. Otherwise it behaves like Run.
+func (n *Nexora) RunUnix(socketPath string) error {
+	return n.run(func() (net.Listener, error) {
+		if info, err := os.Stat(socketPath); err == nil && info.Mode()&os.ModeSocket != 0 {
+			if err := os.Remove(socketPath); err != nil {
+				return nil, err
+			}
+		}
+		return net.Listen("unix", socketPath)
+	}, nil)
+}
+
+// run validates the routes, logs the ambiguous ones, calls the OnStart hooks, opens the listener and
+// serves it, using TLS if tlsConfig is not nil.
+func (n *Nexora) run(listen func() (net.Listener, error), tlsConfig *tls.Config) error {
+	n.lifecycle.mu.Lock()
+	running, hooks := n.lifecycle.servers != nil, n.lifecycle.onStart
+	n.lifecycle.mu.Unlock()
+
+	if running {
+		return ErrServerRunning
+	}
+	if err := n.Validate(); err != nil {
+		return err
+	}
+	for _, w := range n.Warnings() {
+		log.Printf("[nexora] %v", w)
+	}
+	for _, fn := range hooks {
+		if err := fn(); err != nil {
+			return err
+		}
+	}
+
+	ln, err := listen()
+	if err != nil {
+		return err
+	}
+	return n.serve(ln, tlsConfig)
+}
+
+// newServer returns an http.Server configured from the Nexora settings.
+func (n *Nexora) newServer(handler http.Handler) *http.Server {
+	if n.EnableH2C {
+		handler = h2c.NewHandler(handler, &http2.Server{IdleTimeout: n.IdleTimeout})
+	}
+
+	return &http.Server{
+		Handler:           handler,
+		ReadTimeout:       n.ReadTimeout,
+		ReadHeaderTimeout: n.ReadHeaderTimeout,
+		WriteTimeout:      n.WriteTimeout,
+		IdleTimeout:       n.IdleTimeout,
+		MaxHeaderBytes:    n.MaxHeaderBytes,
+	}
+}
+
+// serve serves ln until the server fails or is shut down.
+func (n *Nexora) serve(ln net.Listener, tlsConfig *tls.Config) error {
+	srv := n.newServer(n)
+	srv.TLSConfig = tlsConfig
+	servers := []*http.Server{srv}
+
+	// The redirect server is started before the main server is registered,
+	// so a failure to listen leaves nothing to clean up.
+	var redirectLn net.Listener
+	if tlsConfig != nil && n.RedirectHTTPAddr != "" {
+		var err error
+		if redirectLn, err = net.Listen("tcp", n.RedirectHTTPAddr); err != nil {
+			ln.Close()
+			return err
+		}
+		servers = append(servers, n.newServer(redirectToHTTPS(ln.Addr())))
+	}
+
+	n.lifecycle.mu.Lock()
+	if n.lifecycle.servers != nil {
+		n.lifecycle.mu.Unlock()
+		ln.Close()
+		if redirectLn != nil {
+			redirectLn.Close()
+		}
+		return ErrServerRunning
+	}
+	n.lifecycle.servers = servers
+	n.lifecycle.done = make(chan struct{})
+	n.lifecycle.shutdownErr = nil
+	done := n.lifecycle.done
+	hooks := n.lifecycle.onListen
+	n.lifecycle.mu.Unlock()
+
+	if n.HandleSignals {
+		stop := n.shutdownOnSignal()
+		defer stop()
+	}
+
+	for _, fn := range hooks {
+		if err := fn(ln.Addr()); err != nil {
+			ln.Close()
+			if redirectLn != nil {
+				redirectLn.Close()
+			}
+			n.resetLifecycle()
+			return err
+		}
+	}
+
+	if redirectLn != nil {
+		go func() {
+			if err := servers[1].Serve(redirectLn); !errors.Is(err, http.ErrServerClosed) {
+				log.Printf("[nexora] HTTPS redirect server failed: %v", err)
+			}
+		}()
+	}
+
+	var err error
+	if tlsConfig != nil {
+		err = srv.ServeTLS(ln, "", "")
+	} else {
+		err = srv.Serve(ln)
+	}
+	if !errors.Is(err, http.ErrServerClosed) {
+		if redirectLn != nil {
+			servers[1].Close()
+		}
+		n.resetLifecycle()
+		return err
+	}
+
+	// Serve returns as soon as Shutdown is called, so wait for it to
+	// complete before returning.
+	<-done
+
+	n.lifecycle.mu.Lock()
+	defer n.lifecycle.mu.Unlock()
+	return n.lifecycle.shutdownErr
+}
+
+// redirectToHTTPS returns a handler redirecting requests to the same URL on
+// the HTTPS server listening on addr. GET and HEAD requests are redirected
+// with status 301, other methods with 308 to preserve the method and body.
+func redirectToHTTPS(addr net.Addr) http.Handler {
+	_, port, _ := net.SplitHostPort(addr.String())
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host := r.Host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		if strings.Contains(host, ":") {
+			host = "[" + host + "]"
+		}
+		if port != "" && port != "443" {
+			host += ":" + port
+		}
+
+		code := StatusMovedPermanently
+		if r.Method != MethodGet && r.Method != MethodHead {
+			code = StatusPermanentRedirect
+		}
+
+		http.Redirect(w, r, "https://"+host+r.URL.RequestURI(), code)
+	})
+}
+
+// resetLifecycle marks the server as stopped.
+func (n *Nexora) resetLifecycle() {
+	n.lifecycle.mu.Lock()
+	defer n.lifecycle.mu.Unlock()
+	n.lifecycle.servers = nil
+}
+
+// Shutdown gracefully shuts the server down: it stops accepting connections,
+// waits for active requests to complete and then calls the OnShutdown hooks.
+// If ctx expires first, the context error is returned together with any
+// errors of the hooks. Shutdown does nothing if the server is not running.
+//
+// Hijacked connections, such as WebSockets, are not tracked by the server
+// and must be closed by the application, e.g. in an OnShutdown hook.
+func (n *Nexora) Shutdown(ctx context.Context) error {
+	n.lifecycle.mu.Lock()
+	servers, done := n.lifecycle.servers, n.lifecycle.done
+	hooks := n.lifecycle.onShutdown
+	n.lifecycle.servers = nil
+	n.lifecycle.mu.Unlock()
+
+	if servers == nil {
+		return nil
+	}
+
+	var errs []error
+	for _, srv := range servers {
+		errs = append(errs, srv.Shutdown(ctx))
+	}
+	for i := len(hooks) - 1; i >= 0; i-- {
+		errs = append(errs, hooks[i](ctx))
+	}
+
+	err := errors.Join(errs...)
+
+	n.lifecycle.mu.Lock()
+	n.lifecycle.shutdownErr = err
+	n.lifecycle.mu.Unlock()
+	close(done)
+
+	return err
+}
+
+// shutdownOnSignal shuts the server down when SIGINT or SIGTERM is received.
+// The returned function stops listening for the signals.
+func (n *Nexora) shutdownOnSignal() (stop func()) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+
+	quit := make(chan struct{})
+	go func() {
+		select {
+		case <-signals:
+			timeout := n.ShutdownTimeout
+			if timeout <= 0 {
+				timeout = DefaultShutdownTimeout
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+			n.Shutdown(ctx)
+		case <-quit:
+		}
+	}()
+
+	return func() {
+		signal.Stop(signals)
+		close(quit)
+	}
+}
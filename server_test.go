@@ -0,0 +1,339 @@
+package nexora
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/net/http2"
+)
+
+// startServer runs app on a random port and returns its address and a channel
+// receiving the result of Run.
+func startServer(t *testing.T, app *Nexora) (string, <-chan error) {
+	t.Helper()
+
+	addrs := make(chan string, 1)
+	app.OnListen(func(addr net.Addr) error {
+		addrs <- addr.String()
+		return nil
+	})
+
+	result := make(chan error, 1)
+	go func() { result <- app.Run("127.0.0.1:0") }()
+
+	select {
+	case addr := <-addrs:
+		return addr, result
+	case err := <-result:
+		t.Fatalf("Run() = %v", err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("server did not start")
+	}
+	return "", nil
+}
+
+func TestNexora_RunAndShutdown(t *testing.T) {
+	var calls []string
+
+	app := New()
+	app.ReadTimeout = 3 * time.Second
+	app.Get("/ping", func(c *Context) error { return c.SendString("pong") })
+	app.OnStart(func() error {
+		calls = append(calls, "start")
+		return nil
+	})
+	app.OnShutdown(func(ctx context.Context) error {
+		calls = append(calls, "shutdown 1")
+		return nil
+	})
+	app.OnShutdown(func(ctx context.Context) error {
+		calls = append(calls, "shutdown 2")
+		return nil
+	})
+
+	addr, result := startServer(t, app)
+
+	resp, err := http.Get("http://" + addr + "/ping")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(body) != "pong" {
+		t.Errorf("body = %q, want %q", body, "pong")
+	}
+
+	if err := app.Run("127.0.0.1:0"); err != ErrServerRunning {
+		t.Errorf("second Run() = %v, want %v", err, ErrServerRunning)
+	}
+
+	if err := app.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() = %v", err)
+	}
+	if err := <-result; err != nil {
+		t.Errorf("Run() = %v, want nil after shutdown", err)
+	}
+
+	if got := strings.Join(calls, ","); got != "start,shutdown 2,shutdown 1" {
+		t.Errorf("hook calls = %s", got)
+	}
+}
+
+func TestNexora_ShutdownDrainsRequests(t *testing.T) {
+	started, release := make(chan struct{}), make(chan struct{})
+
+	app := New()
+	app.Get("/slow", func(c *Context) error {
+		close(started)
+		<-release
+		return c.SendString("done")
+	})
+
+	addr, result := startServer(t, app)
+
+	type response struct {
+		body string
+		err  error
+	}
+	responses := make(chan response, 1)
+	go func() {
+		resp, err := http.Get("http://" + addr + "/slow")
+		if err != nil {
+			responses <- response{err: err}
+			return
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		responses <- response{string(body), err}
+	}()
+	<-started
+
+	shutdown := make(chan error, 1)
+	go func() { shutdown <- app.Shutdown(context.Background()) }()
+
+	select {
+	case err := <-shutdown:
+		t.Fatalf("Shutdown() returned before the request completed: %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+
+	if r := <-responses; r.err != nil || r.body != "done" {
+		t.Errorf("in-flight request = %q, %v", r.body, r.err)
+	}
+	if err := <-shutdown; err != nil {
+		t.Errorf("Shutdown() = %v", err)
+	}
+	if err := <-result; err != nil {
+		t.Errorf("Run() = %v", err)
+	}
+}
+
+func TestNexora_ShutdownErrors(t *testing.T) {
+	hookErr := errors.New("flush failed")
+
+	app := New()
+	app.OnShutdown(func(ctx context.Context) error { return hookErr })
+
+	if err := app.Shutdown(context.Background()); err != nil {
+		t.Errorf("Shutdown() of a stopped server = %v, want nil", err)
+	}
+
+	_, result := startServer(t, app)
+
+	if err := app.Shutdown(context.Background()); !errors.Is(err, hookErr) {
+		t.Errorf("Shutdown() = %v, want %v", err, hookErr)
+	}
+	if err := <-result; !errors.Is(err, hookErr) {
+		t.Errorf("Run() = %v, want %v", err, hookErr)
+	}
+}
+
+func TestNexora_HookErrorsAbortRun(t *testing.T) {
+	startErr := errors.New("migrations failed")
+
+	app := New()
+	app.OnStart(func() error { return startErr })
+	if err := app.Run("127.0.0.1:0"); err != startErr {
+		t.Errorf("Run() = %v, want %v", err, startErr)
+	}
+
+	listenErr := errors.New("registration failed")
+
+	app = New()
+	app.OnListen(func(addr net.Addr) error { return listenErr })
+	if err := app.Run("127.0.0.1:0"); err != listenErr {
+		t.Errorf("Run() = %v, want %v", err, listenErr)
+	}
+}
+
+func TestNexora_HandleSignals(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("sending signals is not supported on Windows")
+	}
+
+	shutdown := make(chan struct{})
+
+	app := New()
+	app.HandleSignals = true
+	app.ShutdownTimeout = time.Second
+	app.OnShutdown(func(ctx context.Context) error {
+		close(shutdown)
+		return nil
+	})
+
+	_, result := startServer(t, app)
+
+	process, err := os.FindProcess(os.Getpid())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := process.Signal(os.Interrupt); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case <-shutdown:
+	case <-time.After(5 * time.Second):
+		t.Fatal("server was not shut down on SIGINT")
+	}
+	if err := <-result; err != nil {
+		t.Errorf("Run() = %v", err)
+	}
+}
+
+func TestNexora_RunListener(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	app := New()
+	app.Get("/ping", func(c *Context) error { return c.SendString("pong") })
+
+	result := make(chan error, 1)
+	go func() { result <- app.RunListener(ln) }()
+
+	resp, err := http.Get("http://" + ln.Addr().String() + "/ping")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(body) != "pong" {
+		t.Errorf("body = %q, want %q", body, "pong")
+	}
+
+	app.Shutdown(context.Background())
+	if err := <-result; err != nil {
+		t.Errorf("RunListener() = %v", err)
+	}
+}
+
+func TestNexora_RunUnix(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Unix domain sockets are not supported on all Windows versions")
+	}
+
+	socketPath := filepath.Join(t.TempDir(), "app.sock")
+
+	// A stale socket from a previous process is replaced
+	stale, err := net.Listen("unix", socketPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	stale.Close()
+
+	app := New()
+	app.Get("/ping", func(c *Context) error { return c.SendString("pong") })
+
+	started := make(chan struct{})
+	app.OnListen(func(addr net.Addr) error {
+		close(started)
+		return nil
+	})
+	result := make(chan error, 1)
+	go func() { result <- app.RunUnix(socketPath) }()
+
+	select {
+	case <-started:
+	case err := <-result:
+		t.Fatalf("RunUnix() = %v", err)
+	}
+
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", socketPath)
+		},
+	}}
+	resp, err := client.Get("http://unix/ping")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(body) != "pong" {
+		t.Errorf("body = %q, want %q", body, "pong")
+	}
+
+	app.Shutdown(context.Background())
+	if err := <-result; err != nil {
+		t.Errorf("RunUnix() = %v", err)
+	}
+	if _, err := os.Stat(socketPath); !os.IsNotExist(err) {
+		t.Errorf("socket file not removed after shutdown: %v", err)
+	}
+}
+
+func TestNexora_H2C(t *testing.T) {
+	app := New()
+	app.EnableH2C = true
+	app.Get("/proto", func(c *Context) error { return c.SendString(c.Request().Proto) })
+
+	addr, result := startServer(t, app)
+
+	transport := &http2.Transport{
+		AllowHTTP: true,
+		DialTLSContext: func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
+			return new(net.Dialer).DialContext(ctx, network, addr)
+		},
+	}
+	defer transport.CloseIdleConnections()
+	client := &http.Client{Transport: transport}
+
+	resp, err := client.Get("http://" + addr + "/proto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(body) != "HTTP/2.0" {
+		t.Errorf("protocol = %q, want HTTP/2.0", body)
+	}
+
+	// HTTP/1 keeps working
+	resp, err = http.Get("http://" + addr + "/proto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ = io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(body) != "HTTP/1.1" {
+		t.Errorf("protocol = %q, want HTTP/1.1", body)
+	}
+
+	app.Shutdown(context.Background())
+	<-result
+}
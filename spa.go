@@ -0,0 +1,100 @@
+package nexora
+
+import (
+	"io/fs"
+	"path"
+	"strings"
+)
+
+// SPAConfig configures how a single-page application is served by SPA.
+type SPAConfig struct {
+	// Configures how the assets are served. Index names the page that is
+	// served for client-side routes, and defaults to "index.html".
+	StaticConfig
+
+	// Path prefixes, such as "/api", that never fall back to the index page.
+	// Unmatched requests below them are answered with ErrNotFound.
+	Exclude []string
+}
+
+// SPA serves a single-page application from fsys under the given prefix,
+// using HTML5 history fallback: GET and HEAD requests that do not match a file
+// are answered with the index page, so the application can route them itself.
+//
+// Only requests that accept HTML and whose last path segment has no extension
+// fall back, so a missing "app.js" still results in ErrNotFound. Routes
+// registered in the tree, such as "/api/users", take precedence over the SPA.
+//
+// Example:
+//
+//	//go:embed dist
+//	var dist embed.FS
+//
+//	ui, _ := fs.Sub(dist, "dist")
+//	app.SPA("/", ui, nexora.SPAConfig{Exclude: []string{"/api"}})
+func (g *RouteGroup) SPA(prefix string, fsys fs.FS, config ...SPAConfig) *Route {
+	var cfg SPAConfig
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+	cfg.StaticConfig = staticConfig([]StaticConfig{cfg.StaticConfig})
+	cfg.Browse = false
+
+	handler := func(c *Context) error {
+		if cfg.excluded(c.Path()) {
+			return ErrNotFound
+		}
+
+		err := c.serveFS(fsys, c.Param("filepath"), cfg.StaticConfig)
+		if err != ErrNotFound || !c.acceptsFallback() {
+			return err
+		}
+
+		info, statErr := fs.Stat(fsys, cfg.Index)
+		if statErr != nil || info.IsDir() {
+			return err
+		}
+
+		// The index page of the application must be revalidated, so clients
+		// pick up new builds.
+		fallback := cfg.StaticConfig
+		fallback.MaxAge = 0
+		c.SetHeader(HeaderCacheControl, "no-cache")
+		return c.serveFile(fsys, cfg.Index, info, fallback)
+	}
+
+	pattern := strings.TrimRight(prefix, "/") + "/{filepath:*}"
+	return &Route{
+		group:    g,
+		method:   MethodGet,
+		path:     pattern,
+		template: buildURLTemplate(g.prefix + pattern),
+		routes: []*Route{
+			g.Get(pattern, handler),
+			g.Head(pattern, handler),
+		},
+	}
+}
+
+// excluded reports whether the request path is below one of the excluded prefixes.
+func (cfg *SPAConfig) excluded(requestPath string) bool {
+	for _, prefix := range cfg.Exclude {
+		prefix = strings.TrimRight(prefix, "/")
+		if requestPath == prefix || strings.HasPrefix(requestPath, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// acceptsFallback reports whether the request is a page navigation that may
+// be answered with the index page of a single-page application.
+func (c *Context) acceptsFallback() bool {
+	if c.Method() != MethodGet && c.Method() != MethodHead {
+		return false
+	}
+	if path.Ext(c.Path()) != "" {
+		return false
+	}
+	return c.Accepts(MIMETextHTML) != ""
+}
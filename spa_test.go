@@ -0,0 +1,77 @@
+package nexora
+
+import (
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func TestSPA(t *testing.T) {
+	ui := fstest.MapFS{
+		"index.html":       {Data: []byte("<div id=root></div>")},
+		"assets/app.js":    {Data: []byte("render()")},
+		"assets/style.css": {Data: []byte("body{}")},
+	}
+
+	app := New()
+	app.Get("/api/users", func(c *Context) error { return c.SendString("users") })
+	app.SPA("/", ui, SPAConfig{Exclude: []string{"/api"}})
+
+	html := map[string]string{HeaderAccept: "text/html,application/xhtml+xml,*/*;q=0.8"}
+
+	tests := []struct {
+		name     string
+		method   string
+		target   string
+		header   map[string]string
+		wantCode int
+		wantBody string
+	}{
+		{"root", "GET", "/", html, 200, "<div id=root></div>"},
+		{"asset", "GET", "/assets/app.js", nil, 200, "render()"},
+		{"client route", "GET", "/users/42/edit", html, 200, "<div id=root></div>"},
+		{"client route head", "HEAD", "/settings", html, 200, ""},
+		{"client route without accept", "GET", "/settings", nil, 200, "<div id=root></div>"},
+		{"missing asset", "GET", "/assets/missing.js", html, 404, "Not Found\n"},
+		{"json client", "GET", "/settings", map[string]string{HeaderAccept: "application/json"}, 404, "Not Found\n"},
+		{"api route", "GET", "/api/users", html, 200, "users"},
+		{"unknown api route", "GET", "/api/nope", html, 404, "Not Found\n"},
+		{"api prefix", "GET", "/api", html, 404, "Not Found\n"},
+		{"not excluded", "GET", "/apis", html, 200, "<div id=root></div>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serveStatic(t, app, tt.method, tt.target, tt.header)
+			if rec.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantCode)
+			}
+			if rec.Body.String() != tt.wantBody {
+				t.Errorf("body = %q, want %q", rec.Body.String(), tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestSPA_FallbackNotCached(t *testing.T) {
+	ui := fstest.MapFS{
+		"shell.html":    {Data: []byte("shell")},
+		"assets/app.js": {Data: []byte("render()")},
+	}
+
+	app := New()
+	app.Group("/app").SPA("/", ui, SPAConfig{StaticConfig: StaticConfig{Index: "shell.html", MaxAge: time.Hour}})
+
+	rec := serveStatic(t, app, "GET", "/app/dashboard", nil)
+	if rec.Code != 200 || rec.Body.String() != "shell" {
+		t.Fatalf("fallback = %d %q, want 200 %q", rec.Code, rec.Body.String(), "shell")
+	}
+	if cc := rec.Header().Get(HeaderCacheControl); cc != "no-cache" {
+		t.Errorf("fallback Cache-Control = %q, want %q", cc, "no-cache")
+	}
+
+	rec = serveStatic(t, app, "GET", "/app/assets/app.js", nil)
+	if cc := rec.Header().Get(HeaderCacheControl); cc != "public, max-age=3600" {
+		t.Errorf("asset Cache-Control = %q, want %q", cc, "public, max-age=3600")
+	}
+}
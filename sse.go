@@ -0,0 +1,202 @@
+package nexora
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrEventStreamClosed is returned when sending to an event stream that has
+// been closed, or whose client has disconnected.
+var ErrEventStreamClosed = errors.New("nexora: event stream closed")
+
+// lineBreaks normalizes the line breaks of event data and comments.
+var lineBreaks = strings.NewReplacer("\r\n", "\n", "\r", "\n")
+
+// EventStream writes Server-Sent Events to the client.
+// Its methods are safe for concurrent use.
+type EventStream struct {
+	c      *Context
+	ctx    context.Context
+	cancel context.CancelFunc
+	mu     sync.Mutex     // Serializes writes to the response
+	wg     sync.WaitGroup // Tracks the keep-alive goroutine
+}
+
+// SSE starts a Server-Sent Events stream, sending the response header
+// immediately. The stream is closed when the client disconnects, when Close
+// is called or at the latest when the handler returns.
+//
+// http.ErrNotSupported is returned if the response writer cannot be flushed.
+//
+// Example:
+//
+//	stream, err := c.SSE()
+//	if err != nil {
+//	    return err
+//	}
+//	defer stream.Close()
+//
+//	stream.KeepAlive(15 * time.Second)
+//	for {
+//	    select {
+//	    case <-stream.Done():
+//	        return nil
+//	    case stats := <-updates:
+//	        if err := stream.Send("stats", "", stats); err != nil {
+//	            return nil
+//	        }
+//	    }
+//	}
+func (c *Context) SSE() (*EventStream, error) {
+	if _, ok := c.writer.ResponseWriter.(http.Flusher); !ok {
+		return nil, http.ErrNotSupported
+	}
+
+	header := c.writer.Header()
+	header.Set(HeaderContentType, MIMETextEventStream)
+	header.Set(HeaderCacheControl, "no-cache")
+	header.Del(HeaderContentLength)
+	// Disable response buffering in nginx.
+	header.Set("X-Accel-Buffering", "no")
+	if c.request.ProtoMajor == 1 {
+		header.Set(HeaderConnection, "keep-alive")
+	}
+
+	c.writer.WriteHeader(StatusOK)
+	c.writer.flush()
+
+	ctx, cancel := context.WithCancel(c.request.Context())
+	c.eventStream = &EventStream{c: c, ctx: ctx, cancel: cancel}
+	return c.eventStream, nil
+}
+
+// LastEventID returns the ID of the last event received by the client, sent
+// in the Last-Event-ID header when it reconnects. It is used to resume the
+// stream where the client left off.
+func (s *EventStream) LastEventID() string {
+	return s.c.GetHeader(HeaderLastEventID)
+}
+
+// Done returns a channel that is closed when the stream is closed or the
+// client disconnects.
+func (s *EventStream) Done() <-chan struct{} {
+	return s.ctx.Done()
+}
+
+// Send sends an event to the client. The event name and id are optional.
+// Strings and byte slices are sent as they are, other data is encoded as JSON.
+// Data spanning multiple lines is sent as multiple data fields, which the
+// client joins again.
+//
+// ErrEventStreamClosed is returned once the stream has been closed.
+func (s *EventStream) Send(event, id string, data any) error {
+	if strings.ContainsAny(event, "\r\n") || strings.ContainsAny(id, "\r\n\x00") {
+		return errors.New("nexora: event name and id must not contain line breaks")
+	}
+
+	var payload string
+	switch v := data.(type) {
+	case string:
+		payload = v
+	case []byte:
+		payload = string(v)
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		payload = string(b)
+	}
+
+	var b strings.Builder
+	if id != "" {
+		b.WriteString("id: " + id + "\n")
+	}
+	if event != "" {
+		b.WriteString("event: " + event + "\n")
+	}
+	payload = lineBreaks.Replace(payload)
+	for _, line := range strings.Split(payload, "\n") {
+		b.WriteString("data: " + line + "\n")
+	}
+	b.WriteByte('\n')
+
+	return s.write(b.String())
+}
+
+// Retry tells the client how long to wait before reconnecting after the
+// connection is lost.
+func (s *EventStream) Retry(d time.Duration) error {
+	return s.write("retry: " + strconv.FormatInt(d.Milliseconds(), 10) + "\n\n")
+}
+
+// Comment sends a comment, which is ignored by the client. Comments are used
+// to keep idle connections from being closed by proxies.
+func (s *EventStream) Comment(text string) error {
+	var b strings.Builder
+	text = lineBreaks.Replace(text)
+	for _, line := range strings.Split(text, "\n") {
+		b.WriteString(": " + line + "\n")
+	}
+	b.WriteByte('\n')
+	return s.write(b.String())
+}
+
+// KeepAlive sends a comment at the given interval until the stream is closed.
+func (s *EventStream) KeepAlive(interval time.Duration) {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-s.ctx.Done():
+				return
+			case <-ticker.C:
+				if err := s.Comment("keep-alive"); err != nil {
+					return
+				}
+			}
+		}
+	}()
+}
+
+// Close closes the stream and waits for the keep-alive goroutine and the
+// write in progress, if any, to finish. It is called automatically when the
+// handler returns.
+func (s *EventStream) Close() error {
+	s.cancel()
+
+	// Writes check the context with the lock held, so none is in progress
+	// once it is acquired.
+	s.mu.Lock()
+	s.mu.Unlock()
+
+	s.wg.Wait()
+	return nil
+}
+
+// write writes and flushes a chunk of the stream.
+func (s *EventStream) write(chunk string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.ctx.Err() != nil {
+		return ErrEventStreamClosed
+	}
+	if _, err := s.c.writer.Write([]byte(chunk)); err != nil {
+		s.cancel()
+		return err
+	}
+	s.c.writer.flush()
+	return nil
+}
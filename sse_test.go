@@ -0,0 +1,146 @@
+package nexora
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestContext_SSE(t *testing.T) {
+	app := New()
+	app.Get("/events", func(c *Context) error {
+		stream, err := c.SSE()
+		if err != nil {
+			return err
+		}
+		defer stream.Close()
+
+		if err := stream.Retry(3 * time.Second); err != nil {
+			return err
+		}
+		if err := stream.Comment("resume from " + stream.LastEventID()); err != nil {
+			return err
+		}
+		if err := stream.Send("greeting", "1", "hello\nworld"); err != nil {
+			return err
+		}
+		return stream.Send("", "2", map[string]int{"count": 2})
+	})
+
+	srv := httptest.NewServer(app)
+	defer srv.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, srv.URL+"/events", nil)
+	req.Header.Set(HeaderLastEventID, "41")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if ct := resp.Header.Get(HeaderContentType); ct != MIMETextEventStream {
+		t.Errorf("Content-Type = %q, want %q", ct, MIMETextEventStream)
+	}
+	if cc := resp.Header.Get(HeaderCacheControl); cc != "no-cache" {
+		t.Errorf("Cache-Control = %q, want %q", cc, "no-cache")
+	}
+
+	var body strings.Builder
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		body.WriteString(scanner.Text() + "\n")
+	}
+
+	want := "retry: 3000\n\n" +
+		": resume from 41\n\n" +
+		"id: 1\nevent: greeting\ndata: hello\ndata: world\n\n" +
+		"id: 2\ndata: {\"count\":2}\n\n"
+	if body.String() != want {
+		t.Errorf("stream = %q, want %q", body.String(), want)
+	}
+}
+
+func TestEventStream_KeepAlive(t *testing.T) {
+	rec := httptest.NewRecorder()
+	ctx := newContext(nil)
+	ctx.init(httptest.NewRequest(http.MethodGet, "/", nil), rec)
+
+	stream, err := ctx.SSE()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stream.KeepAlive(time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	stream.Close()
+
+	if !strings.Contains(rec.Body.String(), ": keep-alive\n\n") {
+		t.Errorf("expected keep-alive comments, got %q", rec.Body.String())
+	}
+	if err := stream.Send("", "", "late"); err != ErrEventStreamClosed {
+		t.Errorf("Send() after Close error = %v, want %v", err, ErrEventStreamClosed)
+	}
+}
+
+func TestEventStream_ClientDisconnect(t *testing.T) {
+	reqCtx, cancel := context.WithCancel(context.Background())
+	req := httptest.NewRequest(http.MethodGet, "/", nil).WithContext(reqCtx)
+
+	ctx := newContext(nil)
+	ctx.init(req, httptest.NewRecorder())
+
+	stream, err := ctx.SSE()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stream.KeepAlive(time.Hour)
+
+	cancel()
+
+	select {
+	case <-stream.Done():
+	case <-time.After(time.Second):
+		t.Fatal("stream was not terminated when the request context was cancelled")
+	}
+	if err := stream.Send("", "", "data"); err != ErrEventStreamClosed {
+		t.Errorf("Send() error = %v, want %v", err, ErrEventStreamClosed)
+	}
+
+	// The keep-alive goroutine must stop as well
+	ctx.release()
+}
+
+func TestEventStream_InvalidFields(t *testing.T) {
+	ctx := newContext(nil)
+	ctx.init(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder())
+
+	stream, err := ctx.SSE()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stream.Close()
+
+	if err := stream.Send("bad\nevent", "", "x"); err == nil {
+		t.Error("expected error for event name with a line break")
+	}
+	if err := stream.Send("", "bad\nid", "x"); err == nil {
+		t.Error("expected error for id with a line break")
+	}
+}
+
+type nonFlushingWriter struct {
+	http.ResponseWriter
+}
+
+func TestContext_SSENotSupported(t *testing.T) {
+	ctx := newContext(nil)
+	ctx.init(httptest.NewRequest(http.MethodGet, "/", nil), nonFlushingWriter{httptest.NewRecorder()})
+
+	if _, err := ctx.SSE(); err != http.ErrNotSupported {
+		t.Errorf("SSE() error = %v, want %v", err, http.ErrNotSupported)
+	}
+}
@@ -0,0 +1,284 @@
+package nexora
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// StaticConfig configures how files are served by Static and StaticFS.
+type StaticConfig struct {
+	// Enables HTML listings of directories that have no index file.
+	Browse bool
+
+	// Name of the file served for directory requests. Defaults to "index.html".
+	Index string
+
+	// If enabled, precompressed siblings of a file ("app.js.br", "app.js.gz")
+	// are served instead of the file itself when the client accepts their
+	// encoding.
+	Compress bool
+
+	// Sets the max-age of the Cache-Control header, if greater than zero.
+	MaxAge time.Duration
+}
+
+// precompressedEncodings lists the supported encodings of precompressed files
+// and the extension of their files, in order of preference.
+var precompressedEncodings = []struct {
+	encoding, ext string
+}{
+	{"br", ".br"},
+	{"gzip", ".gz"},
+}
+
+// Static serves the files of the root directory under the given prefix, for
+// both GET and HEAD requests.
+//
+// Example:
+//
+//	app.Static("/assets", "./public", nexora.StaticConfig{MaxAge: time.Hour})
+func (g *RouteGroup) Static(prefix, root string, config ...StaticConfig) *Route {
+	return g.StaticFS(prefix, os.DirFS(root), config...)
+}
+
+// StaticFS serves the files of fsys under the given prefix, for both GET and
+// HEAD requests. The files are matched by a {filepath:*} wildcard appended to
+// the prefix.
+//
+// Example:
+//
+//	//go:embed dist
+//	var dist embed.FS
+//
+//	assets, _ := fs.Sub(dist, "dist")
+//	app.StaticFS("/assets", assets)
+func (g *RouteGroup) StaticFS(prefix string, fsys fs.FS, config ...StaticConfig) *Route {
+	cfg := staticConfig(config)
+
+	handler := func(c *Context) error {
+		return c.serveFS(fsys, c.Param("filepath"), cfg)
+	}
+
+	pattern := strings.TrimRight(prefix, "/") + "/{filepath:*}"
+	return &Route{
+		group:    g,
+		method:   MethodGet,
+		path:     pattern,
+		template: buildURLTemplate(g.prefix + pattern),
+		routes: []*Route{
+			g.Get(pattern, handler),
+			g.Head(pattern, handler),
+		},
+	}
+}
+
+// staticConfig returns the first config, with defaults applied.
+func staticConfig(config []StaticConfig) StaticConfig {
+	var cfg StaticConfig
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+	if cfg.Index == "" {
+		cfg.Index = "index.html"
+	}
+	return cfg
+}
+
+// File sends the named file, handling conditional and range requests.
+// ErrNotFound is returned if the file does not exist or is a directory.
+//
+// Example:
+//
+//	return c.File("reports/summary.pdf")
+func (c *Context) File(file string) error {
+	info, err := os.Stat(file)
+	if err != nil {
+		return fsError(err)
+	}
+	if info.IsDir() {
+		return ErrNotFound
+	}
+	return c.serveFile(os.DirFS(filepath.Dir(file)), filepath.Base(file), info, StaticConfig{})
+}
+
+// Attachment sends the named file like File, with a Content-Disposition header
+// prompting the client to save it under the given name.
+//
+// Example:
+//
+//	return c.Attachment("reports/2024.pdf", "report.pdf")
+func (c *Context) Attachment(file, name string) error {
+	c.SetHeader(HeaderContentDisposition, mime.FormatMediaType("attachment", map[string]string{"filename": name}))
+	return c.File(file)
+}
+
+// serveFS serves the named file or directory of fsys.
+// ErrNotFound is returned if it does not exist or cannot be served.
+func (c *Context) serveFS(fsys fs.FS, name string, cfg StaticConfig) error {
+	name = strings.TrimPrefix(path.Clean("/"+name), "/")
+	if name == "" {
+		name = "."
+	}
+	if !fs.ValidPath(name) {
+		return ErrNotFound
+	}
+
+	info, err := fs.Stat(fsys, name)
+	if err != nil {
+		return fsError(err)
+	}
+
+	if !info.IsDir() {
+		return c.serveFile(fsys, name, info, cfg)
+	}
+
+	// Directories are always addressed with a trailing slash, so that
+	// relative links in index files and listings resolve correctly.
+	if !strings.HasSuffix(c.request.URL.Path, "/") {
+		location := c.request.URL.Path + "/"
+		if c.request.URL.RawQuery != "" {
+			location += "?" + c.request.URL.RawQuery
+		}
+		http.Redirect(c.response, c.request, location, StatusMovedPermanently)
+		return nil
+	}
+
+	index := path.Join(name, cfg.Index)
+	if info, err := fs.Stat(fsys, index); err == nil && !info.IsDir() {
+		return c.serveFile(fsys, index, info, cfg)
+	}
+
+	if cfg.Browse {
+		return c.listDir(fsys, name)
+	}
+
+	return ErrNotFound
+}
+
+// serveFile serves a regular file, or one of its precompressed siblings.
+func (c *Context) serveFile(fsys fs.FS, name string, info fs.FileInfo, cfg StaticConfig) error {
+	served, servedInfo := name, info
+
+	if cfg.Compress {
+		c.addVary(HeaderAcceptEncoding)
+		if encoding, file, fileInfo := c.precompressed(fsys, name); encoding != "" {
+			c.SetHeader(HeaderContentEncoding, encoding)
+			served, servedInfo = file, fileInfo
+		}
+	}
+
+	f, err := fsys.Open(served)
+	if err != nil {
+		return fsError(err)
+	}
+	defer f.Close()
+
+	content, ok := f.(io.ReadSeeker)
+	if !ok {
+		// Files of some fs.FS implementations cannot seek, so buffer them
+		// to support range requests.
+		data, err := io.ReadAll(f)
+		if err != nil {
+			return err
+		}
+		content = bytes.NewReader(data)
+	}
+
+	header := c.writer.Header()
+	if header.Get(HeaderContentType) == "" {
+		// Set the type from the original name, as sniffing the content of
+		// a compressed file gives the wrong result.
+		contentType := mime.TypeByExtension(path.Ext(name))
+		if contentType == "" && served != name {
+			contentType = MIMEOctetStream
+		}
+		if contentType != "" {
+			c.SetContentType(contentType)
+		}
+	}
+	if header.Get(HeaderETag) == "" {
+		c.SetHeader(HeaderETag, fmt.Sprintf(`"%x-%x"`, servedInfo.ModTime().UnixNano(), servedInfo.Size()))
+	}
+	if cfg.MaxAge > 0 {
+		c.SetHeader(HeaderCacheControl, "public, max-age="+strconv.FormatInt(int64(cfg.MaxAge/time.Second), 10))
+	}
+
+	http.ServeContent(c.response, c.request, name, servedInfo.ModTime(), content)
+	return nil
+}
+
+// precompressed returns the encoding, name and info of the precompressed
+// sibling of name that is preferred by the client, if any.
+func (c *Context) precompressed(fsys fs.FS, name string) (string, string, fs.FileInfo) {
+	if c.GetHeader(HeaderAcceptEncoding) == "" {
+		return "", "", nil
+	}
+
+	var (
+		offers []string
+		files  = make(map[string]string, len(precompressedEncodings))
+		infos  = make(map[string]fs.FileInfo, len(precompressedEncodings))
+	)
+	for _, pc := range precompressedEncodings {
+		info, err := fs.Stat(fsys, name+pc.ext)
+		if err != nil || info.IsDir() {
+			continue
+		}
+		offers = append(offers, pc.encoding)
+		files[pc.encoding], infos[pc.encoding] = name+pc.ext, info
+	}
+
+	encoding := c.AcceptsEncodings(offers...)
+	if encoding == "" {
+		return "", "", nil
+	}
+	return encoding, files[encoding], infos[encoding]
+}
+
+// listDir sends an HTML listing of the entries of a directory.
+func (c *Context) listDir(fsys fs.FS, name string) error {
+	entries, err := fs.ReadDir(fsys, name)
+	if err != nil {
+		return fsError(err)
+	}
+
+	var b strings.Builder
+	title := html.EscapeString(c.request.URL.Path)
+	b.WriteString("<!doctype html>\n<meta name=\"viewport\" content=\"width=device-width\">\n")
+	b.WriteString("<title>Index of " + title + "</title>\n<h1>Index of " + title + "</h1>\n<pre>\n")
+	for _, entry := range entries {
+		entryName := entry.Name()
+		if entry.IsDir() {
+			entryName += "/"
+		}
+		href := (&url.URL{Path: entryName}).String()
+		fmt.Fprintf(&b, "<a href=\"%s\">%s</a>\n", html.EscapeString(href), html.EscapeString(entryName))
+	}
+	b.WriteString("</pre>\n")
+
+	return c.HTML(StatusOK, b.String())
+}
+
+// fsError maps errors from a file system to an *HTTPError.
+func fsError(err error) error {
+	switch {
+	case errors.Is(err, fs.ErrNotExist), errors.Is(err, fs.ErrInvalid):
+		return ErrNotFound
+	case errors.Is(err, fs.ErrPermission):
+		return ErrForbidden
+	}
+	return err
+}
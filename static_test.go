@@ -0,0 +1,237 @@
+package nexora
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func staticTestFS() fstest.MapFS {
+	modTime := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	return fstest.MapFS{
+		"index.html":       {Data: []byte("<h1>home</h1>"), ModTime: modTime},
+		"css/site.css":     {Data: []byte("body{color:red}"), ModTime: modTime},
+		"js/app.js":        {Data: []byte("console.log('plain')"), ModTime: modTime},
+		"js/app.js.gz":     {Data: []byte("gzip-bytes"), ModTime: modTime},
+		"js/app.js.br":     {Data: []byte("br-bytes"), ModTime: modTime},
+		"docs/readme.txt":  {Data: []byte("0123456789"), ModTime: modTime},
+		"docs/<b>odd.txt":  {Data: []byte("odd"), ModTime: modTime},
+		"docs/sub/sub.txt": {Data: []byte("sub"), ModTime: modTime},
+	}
+}
+
+func serveStatic(t *testing.T, app *Nexora, method, target string, header map[string]string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(method, target, nil)
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestStaticFS(t *testing.T) {
+	app := New()
+	app.StaticFS("/assets", staticTestFS(), StaticConfig{MaxAge: time.Hour})
+
+	tests := []struct {
+		name     string
+		method   string
+		target   string
+		wantCode int
+		wantBody string
+		wantType string
+	}{
+		{"file", "GET", "/assets/css/site.css", 200, "body{color:red}", "text/css; charset=utf-8"},
+		{"head", "HEAD", "/assets/css/site.css", 200, "", "text/css; charset=utf-8"},
+		{"index", "GET", "/assets/", 200, "<h1>home</h1>", "text/html; charset=utf-8"},
+		{"missing", "GET", "/assets/nope.css", 404, "Not Found\n", ""},
+		{"traversal", "GET", "/assets/../static_test.go", 404, "", ""},
+		{"directory without index", "GET", "/assets/docs/", 404, "Not Found\n", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serveStatic(t, app, tt.method, tt.target, nil)
+			if rec.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantCode)
+			}
+			if tt.wantBody != "" && rec.Body.String() != tt.wantBody {
+				t.Errorf("body = %q, want %q", rec.Body.String(), tt.wantBody)
+			}
+			if tt.wantType != "" && rec.Header().Get(HeaderContentType) != tt.wantType {
+				t.Errorf("Content-Type = %q, want %q", rec.Header().Get(HeaderContentType), tt.wantType)
+			}
+			if tt.wantCode == 200 && rec.Header().Get(HeaderCacheControl) != "public, max-age=3600" {
+				t.Errorf("Cache-Control = %q", rec.Header().Get(HeaderCacheControl))
+			}
+		})
+	}
+}
+
+func TestStaticFS_DirectoryRedirect(t *testing.T) {
+	app := New()
+	app.StaticFS("/assets", staticTestFS())
+
+	rec := serveStatic(t, app, "GET", "/assets/docs?x=1", nil)
+	if rec.Code != StatusMovedPermanently {
+		t.Fatalf("status = %d, want 301", rec.Code)
+	}
+	if loc := rec.Header().Get(HeaderLocation); loc != "/assets/docs/?x=1" {
+		t.Errorf("Location = %q, want %q", loc, "/assets/docs/?x=1")
+	}
+}
+
+func TestStaticFS_Conditional(t *testing.T) {
+	app := New()
+	app.StaticFS("/assets", staticTestFS())
+
+	rec := serveStatic(t, app, "GET", "/assets/docs/readme.txt", nil)
+	etag := rec.Header().Get(HeaderETag)
+	lastModified := rec.Header().Get(HeaderLastModified)
+	if etag == "" || lastModified == "" {
+		t.Fatalf("ETag = %q, Last-Modified = %q, want both set", etag, lastModified)
+	}
+
+	rec = serveStatic(t, app, "GET", "/assets/docs/readme.txt", map[string]string{HeaderIfNoneMatch: etag})
+	if rec.Code != StatusNotModified {
+		t.Errorf("If-None-Match status = %d, want 304", rec.Code)
+	}
+
+	rec = serveStatic(t, app, "GET", "/assets/docs/readme.txt", map[string]string{HeaderIfModifiedSince: lastModified})
+	if rec.Code != StatusNotModified {
+		t.Errorf("If-Modified-Since status = %d, want 304", rec.Code)
+	}
+}
+
+func TestStaticFS_Range(t *testing.T) {
+	app := New()
+	app.StaticFS("/assets", staticTestFS())
+
+	rec := serveStatic(t, app, "GET", "/assets/docs/readme.txt", map[string]string{HeaderRange: "bytes=2-5"})
+	if rec.Code != StatusPartialContent {
+		t.Fatalf("status = %d, want 206", rec.Code)
+	}
+	if rec.Body.String() != "2345" {
+		t.Errorf("body = %q, want %q", rec.Body.String(), "2345")
+	}
+	if cr := rec.Header().Get(HeaderContentRange); cr != "bytes 2-5/10" {
+		t.Errorf("Content-Range = %q, want %q", cr, "bytes 2-5/10")
+	}
+
+	rec = serveStatic(t, app, "GET", "/assets/docs/readme.txt", map[string]string{HeaderRange: "bytes=20-30"})
+	if rec.Code != StatusRequestedRangeNotSatisfiable {
+		t.Errorf("unsatisfiable range status = %d, want 416", rec.Code)
+	}
+}
+
+func TestStaticFS_Precompressed(t *testing.T) {
+	app := New()
+	app.StaticFS("/assets", staticTestFS(), StaticConfig{Compress: true})
+
+	tests := []struct {
+		acceptEncoding string
+		wantEncoding   string
+		wantBody       string
+	}{
+		{"", "", "console.log('plain')"},
+		{"gzip", "gzip", "gzip-bytes"},
+		{"gzip, br", "br", "br-bytes"},
+		{"br;q=0.5, gzip", "gzip", "gzip-bytes"},
+		{"deflate", "", "console.log('plain')"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.acceptEncoding, func(t *testing.T) {
+			rec := serveStatic(t, app, "GET", "/assets/js/app.js", map[string]string{HeaderAcceptEncoding: tt.acceptEncoding})
+			if got := rec.Header().Get(HeaderContentEncoding); got != tt.wantEncoding {
+				t.Errorf("Content-Encoding = %q, want %q", got, tt.wantEncoding)
+			}
+			if rec.Body.String() != tt.wantBody {
+				t.Errorf("body = %q, want %q", rec.Body.String(), tt.wantBody)
+			}
+			if ct := rec.Header().Get(HeaderContentType); !strings.Contains(ct, "javascript") {
+				t.Errorf("Content-Type = %q, want a JavaScript type", ct)
+			}
+			if rec.Header().Get(HeaderVary) != HeaderAcceptEncoding {
+				t.Errorf("Vary = %q, want %q", rec.Header().Get(HeaderVary), HeaderAcceptEncoding)
+			}
+		})
+	}
+}
+
+func TestStaticFS_Browse(t *testing.T) {
+	app := New()
+	app.StaticFS("/files", staticTestFS(), StaticConfig{Browse: true})
+
+	rec := serveStatic(t, app, "GET", "/files/docs/", nil)
+	if rec.Code != StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+
+	body := rec.Body.String()
+	for _, want := range []string{
+		`<a href="readme.txt">readme.txt</a>`,
+		`<a href="sub/">sub/</a>`,
+		`<a href="%3Cb%3Eodd.txt">&lt;b&gt;odd.txt</a>`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("listing does not contain %q:\n%s", want, body)
+		}
+	}
+}
+
+func TestStatic_Dir(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "hello.txt"), []byte("hello"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	app := New()
+	route := app.Static("/public/", dir)
+	if len(route.routes) != 2 {
+		t.Errorf("expected GET and HEAD routes, got %d", len(route.routes))
+	}
+
+	rec := serveStatic(t, app, "GET", "/public/hello.txt", nil)
+	if rec.Code != StatusOK || rec.Body.String() != "hello" {
+		t.Errorf("got %d %q, want 200 %q", rec.Code, rec.Body.String(), "hello")
+	}
+}
+
+func TestContext_FileAndAttachment(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "report.csv")
+	if err := os.WriteFile(file, []byte("a,b\n1,2\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	app := New()
+	app.Get("/file", func(c *Context) error { return c.File(file) })
+	app.Get("/download", func(c *Context) error { return c.Attachment(file, "résumé.csv") })
+	app.Get("/dir", func(c *Context) error { return c.File(dir) })
+
+	rec := serveStatic(t, app, "GET", "/file", nil)
+	if rec.Code != StatusOK || rec.Body.String() != "a,b\n1,2\n" {
+		t.Errorf("File() = %d %q", rec.Code, rec.Body.String())
+	}
+	if rec.Header().Get(HeaderContentDisposition) != "" {
+		t.Errorf("File() set Content-Disposition %q", rec.Header().Get(HeaderContentDisposition))
+	}
+
+	rec = serveStatic(t, app, "GET", "/download", nil)
+	if cd := rec.Header().Get(HeaderContentDisposition); cd != "attachment; filename*=utf-8''r%C3%A9sum%C3%A9.csv" {
+		t.Errorf("Content-Disposition = %q", cd)
+	}
+
+	rec = serveStatic(t, app, "GET", "/dir", nil)
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("File(dir) status = %d, want 404", rec.Code)
+	}
+}
@@ -0,0 +1,75 @@
+package nexora
+
+import (
+	"crypto/tls"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// certCheckInterval is the minimum time between checks of the certificate
+// files for changes.
+var certCheckInterval = time.Second
+
+// certReloader serves a TLS certificate from files, reloading it when the
+// files change.
+type certReloader struct {
+	certFile, keyFile string
+
+	mu      sync.Mutex
+	cert    *tls.Certificate
+	modTime time.Time // Latest modification time of the files
+	checked time.Time // Last time the files were checked
+}
+
+// newCertReloader loads the certificate and key from the given PEM files.
+func newCertReloader(certFile, keyFile string) (*certReloader, error) {
+	r := &certReloader{certFile: certFile, keyFile: keyFile}
+	if err := r.reload(); err != nil {
+		return nil, err
+	}
+	r.checked = time.Now()
+	return r, nil
+}
+
+// GetCertificate implements tls.Config.GetCertificate.
+func (r *certReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if now := time.Now(); now.Sub(r.checked) >= certCheckInterval {
+		r.checked = now
+		if err := r.reload(); err != nil {
+			log.Printf("[nexora] failed to reload TLS certificate, keeping the previous one: %v", err)
+		}
+	}
+
+	return r.cert, nil
+}
+
+// reload loads the certificate if the files changed since the last load.
+func (r *certReloader) reload() error {
+	var modTime time.Time
+	for _, name := range []string{r.certFile, r.keyFile} {
+		info, err := os.Stat(name)
+		if err != nil {
+			return err
+		}
+		if info.ModTime().After(modTime) {
+			modTime = info.ModTime()
+		}
+	}
+
+	if r.cert != nil && modTime.Equal(r.modTime) {
+		return nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return err
+	}
+
+	r.cert, r.modTime = &cert, modTime
+	return nil
+}
@@ -0,0 +1,213 @@
+package nexora
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"log"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeTestCert writes a self-signed certificate for 127.0.0.1 with the given
+// common name, and sets the modification time of the files to modTime.
+func writeTestCert(t *testing.T, dir, commonName string, modTime time.Time) (certFile, keyFile string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certFile, keyFile = filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{certFile, keyFile} {
+		if err := os.Chtimes(name, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return certFile, keyFile
+}
+
+func certCommonName(t *testing.T, r *certReloader) string {
+	t.Helper()
+	cert, err := r.GetCertificate(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return leaf.Subject.CommonName
+}
+
+func TestCertReloader(t *testing.T) {
+	dir := t.TempDir()
+	start := time.Now().Add(-time.Hour)
+	certFile, keyFile := writeTestCert(t, dir, "first", start)
+
+	r, err := newCertReloader(certFile, keyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name := certCommonName(t, r); name != "first" {
+		t.Fatalf("certificate = %q, want %q", name, "first")
+	}
+
+	// Rotated files are picked up after the check interval
+	writeTestCert(t, dir, "second", start.Add(time.Minute))
+	if name := certCommonName(t, r); name != "first" {
+		t.Errorf("certificate reloaded before the check interval: %q", name)
+	}
+	r.checked = time.Time{}
+	if name := certCommonName(t, r); name != "second" {
+		t.Errorf("certificate = %q, want %q", name, "second")
+	}
+
+	// A broken rotation keeps the previous certificate and is logged
+	var logBuf bytes.Buffer
+	defer log.SetOutput(log.Writer())
+	log.SetOutput(&logBuf)
+
+	if err := os.WriteFile(keyFile, []byte("garbage"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	r.checked = time.Time{}
+	if name := certCommonName(t, r); name != "second" {
+		t.Errorf("certificate = %q after a failed reload, want %q", name, "second")
+	}
+	if !strings.Contains(logBuf.String(), "failed to reload TLS certificate") {
+		t.Errorf("failed reload not logged: %q", logBuf.String())
+	}
+
+	if _, err := newCertReloader(filepath.Join(dir, "missing.crt"), keyFile); err == nil {
+		t.Error("expected error for missing certificate file")
+	}
+}
+
+func TestNexora_RunTLS(t *testing.T) {
+	certFile, keyFile := writeTestCert(t, t.TempDir(), "nexora", time.Now())
+
+	// Reserve a port for the redirect server
+	probe, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	redirectAddr := probe.Addr().String()
+	probe.Close()
+
+	app := New()
+	app.RedirectHTTPAddr = redirectAddr
+	app.Get("/proto", func(c *Context) error { return c.SendString(c.Request().Proto) })
+
+	addrs := make(chan string, 1)
+	app.OnListen(func(addr net.Addr) error {
+		addrs <- addr.String()
+		return nil
+	})
+	result := make(chan error, 1)
+	go func() { result <- app.RunTLS("127.0.0.1:0", certFile, keyFile) }()
+
+	var addr string
+	select {
+	case addr = <-addrs:
+	case err := <-result:
+		t.Fatalf("RunTLS() = %v", err)
+	}
+
+	pool := x509.NewCertPool()
+	pem, _ := os.ReadFile(certFile)
+	pool.AppendCertsFromPEM(pem)
+	client := &http.Client{
+		Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool}, ForceAttemptHTTP2: true},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	resp, err := client.Get("https://" + addr + "/proto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(body) != "HTTP/2.0" {
+		t.Errorf("protocol = %q, want HTTP/2.0", body)
+	}
+
+	resp, err = client.Get("http://" + redirectAddr + "/proto?x=1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if want := "https://" + addr + "/proto?x=1"; resp.StatusCode != StatusMovedPermanently || resp.Header.Get(HeaderLocation) != want {
+		t.Errorf("redirect = %d %q, want 301 %q", resp.StatusCode, resp.Header.Get(HeaderLocation), want)
+	}
+
+	if err := app.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-result; err != nil {
+		t.Errorf("RunTLS() = %v", err)
+	}
+	if _, err := client.Get("http://" + redirectAddr + "/"); err == nil {
+		t.Error("redirect server still running after shutdown")
+	}
+}
+
+func TestRedirectToHTTPS(t *testing.T) {
+	tests := []struct {
+		tlsAddr, method, target, want string
+		wantCode                      int
+	}{
+		{"0.0.0.0:443", "GET", "http://example.com/a?b=c", "https://example.com/a?b=c", StatusMovedPermanently},
+		{"0.0.0.0:443", "GET", "http://example.com:80/", "https://example.com/", StatusMovedPermanently},
+		{"0.0.0.0:8443", "POST", "http://example.com/form", "https://example.com:8443/form", StatusPermanentRedirect},
+		{"[::]:8443", "GET", "http://[::1]:8080/", "https://[::1]:8443/", StatusMovedPermanently},
+	}
+
+	for _, tt := range tests {
+		addr, _ := net.ResolveTCPAddr("tcp", tt.tlsAddr)
+		rec := httptest.NewRecorder()
+		redirectToHTTPS(addr).ServeHTTP(rec, httptest.NewRequest(tt.method, tt.target, nil))
+
+		if rec.Code != tt.wantCode || rec.Header().Get(HeaderLocation) != tt.want {
+			t.Errorf("%s %s = %d %q, want %d %q", tt.method, tt.target, rec.Code, rec.Header().Get(HeaderLocation), tt.wantCode, tt.want)
+		}
+	}
+}
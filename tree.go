@@ -14,14 +14,12 @@ import (
 	"sort"
 	"strings"
 
-	gstrings "github.com/savsgio/gotils/strings"
 	"github.com/valyala/bytebufferpool"
 )
 
 const (
 	errSetHandler         = "nexora: a handler is already registered for path '%s'"
 	errSetWildcardHandler = "nexora: a wildcard handler is already registered for path '%s'"
-	errWildPathConflict   = "nexora: '%s' in new path '%s' conflicts with existing wild path '%s' in existing prefix '%s'"
 	errWildcardConflict   = "nexora: '%s' in new path '%s' conflicts with existing wildcard '%s' in existing prefix '%s'"
 	errWildcardSlash      = "nexora: no / before wildcard in path '%s'"
 	errWildcardNotAtEnd   = "nexora: wildcard routes are only allowed at the end of the path in path '%s'"
@@ -65,8 +63,9 @@ type node struct {
 	children     []*node
 	wildcard     *nodeWildcard
 
-	paramKeys  []string
-	paramRegex *regexp.Regexp
+	paramKeys    []string
+	paramRegex   *regexp.Regexp
+	paramParsers []func(string) (any, error) // Parsers of the values of typed parameters, nil if there are none
 }
 
 type wildPath struct {
@@ -78,6 +77,53 @@ type wildPath struct {
 
 	pattern string
 	regex   *regexp.Regexp
+	parsers []func(string) (any, error) // Parsers of the values of typed parameters, by key
+}
+
+// pathParam is a parameter of a matched path.
+type pathParam struct {
+	key, value string
+	typed      any // Value parsed by the constraint of the parameter, if any
+}
+
+// pathParams holds the parameters of a matched path. The slice of a context
+// is reused across requests and the values are appended to it while matching,
+// so matching a route doesn't allocate, except for parameters with a regular
+// expression: the regexp package allocates the indexes of the submatches.
+type pathParams []pathParam
+
+// get returns the value of the parameter with the given key. If the key is
+// repeated, the last value is returned.
+func (ps pathParams) get(key string) (string, bool) {
+	for i := len(ps) - 1; i >= 0; i-- {
+		if ps[i].key == key {
+			return ps[i].value, true
+		}
+	}
+	return "", false
+}
+
+// typed returns the parsed value of the parameter with the given key, or nil
+// if it has none. If the key is repeated, the value of the last one is returned.
+func (ps pathParams) typed(key string) any {
+	for i := len(ps) - 1; i >= 0; i-- {
+		if ps[i].key == key {
+			return ps[i].typed
+		}
+	}
+	return nil
+}
+
+// toMap returns the parameters as a map, or nil if there are none.
+func (ps pathParams) toMap() map[string]string {
+	if len(ps) == 0 {
+		return nil
+	}
+	m := make(map[string]string, len(ps))
+	for _, p := range ps {
+		m[p.key] = p.value
+	}
+	return m
 }
 
 func newNode(path string) *node {
@@ -94,14 +140,6 @@ func (n *nodeWildcard) conflict(path, fullPath string) error {
 	return newRadixError(errWildcardConflict, path, fullPath, n.path, prefix)
 }
 
-// wildPathConflict raises a panic with some details
-func (n *node) wildPathConflict(path, fullPath string) error {
-	pathSeg := strings.SplitN(path, "/", 2)[0]
-	prefix := fullPath[:strings.LastIndex(fullPath, path)] + n.path
-
-	return newRadixError(errWildPathConflict, pathSeg, fullPath, n.path, prefix)
-}
-
 // clone clones the current node in a new pointer
 func (n node) clone() *node {
 	cloneNode := new(node)
@@ -132,6 +170,7 @@ func (n node) clone() *node {
 	}
 
 	cloneNode.paramRegex = n.paramRegex
+	cloneNode.paramParsers = n.paramParsers
 
 	return cloneNode
 }
@@ -142,6 +181,7 @@ func (n *node) split(i int) {
 	cloneChild.path = cloneChild.path[i:]
 	cloneChild.paramKeys = nil
 	cloneChild.paramRegex = nil
+	cloneChild.paramParsers = nil
 
 	n.path = n.path[:i]
 	n.handlers = nil
@@ -150,29 +190,32 @@ func (n *node) split(i int) {
 	n.children = append(n.children[:0], cloneChild)
 }
 
-func (n *node) findEndIndexAndValues(path string) (int, []string) {
+// matchParams matches the regular expression of the node at the start of the
+// path segment and appends the values of its parameters to params, with the
+// values parsed by their typed constraints. It returns the end of the match,
+// or -1 if it doesn't match or a value is rejected by its typed constraint,
+// in which case params is left unchanged.
+func (n *node) matchParams(path string, params *pathParams) int {
 	index := n.paramRegex.FindStringSubmatchIndex(path)
 	if len(index) == 0 || index[0] != 0 {
-		return -1, nil
+		return -1
 	}
 
-	end := index[1]
-
-	index = index[2:]
-	values := make([]string, len(index)/2)
-
-	i := 0
-	for j := range index {
-		if (j+1)%2 != 0 {
-			continue
+	start := len(*params)
+	for i, key := range n.paramKeys {
+		param := pathParam{key: key, value: path[index[2*i+2]:index[2*i+3]]}
+		if i < len(n.paramParsers) && n.paramParsers[i] != nil {
+			typed, err := n.paramParsers[i](param.value)
+			if err != nil {
+				*params = (*params)[:start]
+				return -1
+			}
+			param.typed = typed
 		}
-
-		values[i] = gstrings.Copy(path[index[j-1]:index[j]])
-
-		i++
+		*params = append(*params, param)
 	}
 
-	return end, values
+	return index[1]
 }
 
 func (n *node) setHandler(handlers []Handler, fullPath string) (*node, error) {
@@ -211,11 +254,11 @@ func (n *node) setHandler(handlers []Handler, fullPath string) (*node, error) {
 	return n, nil
 }
 
-func (n *node) insert(path, fullPath string, handlers []Handler) (*node, error) {
+func (n *node) insert(path, fullPath string, handlers []Handler, constraints constraintResolver) (*node, error) {
 	end := segmentEndIndex(path, true)
 	child := newNode(path)
 
-	wp := findWildPath(path, fullPath)
+	wp := findWildPath(path, fullPath, constraints)
 	if wp != nil {
 		j := end
 		if wp.start > 0 {
@@ -227,7 +270,7 @@ func (n *node) insert(path, fullPath string, handlers []Handler) (*node, error)
 		if wp.start > 0 {
 			n.children = append(n.children, child)
 
-			return child.insert(path[j:], fullPath, handlers)
+			return child.insert(path[j:], fullPath, handlers, constraints)
 		}
 
 		switch wp.pType {
@@ -237,6 +280,12 @@ func (n *node) insert(path, fullPath string, handlers []Handler) (*node, error)
 			child.nType = wp.pType
 			child.paramKeys = wp.keys
 			child.paramRegex = wp.regex
+			for _, parse := range wp.parsers {
+				if parse != nil {
+					child.paramParsers = wp.parsers
+					break
+				}
+			}
 		case wildcard:
 			if len(path) == end && n.path[len(n.path)-1] != '/' {
 				return nil, newRadixError(errWildcardSlash, fullPath)
@@ -273,7 +322,7 @@ func (n *node) insert(path, fullPath string, handlers []Handler) (*node, error)
 		if len(path) > 0 {
 			n.children = append(n.children, child)
 
-			return child.insert(path, fullPath, handlers)
+			return child.insert(path, fullPath, handlers, constraints)
 		}
 	}
 
@@ -296,7 +345,7 @@ func (n *node) insert(path, fullPath string, handlers []Handler) (*node, error)
 }
 
 // add adds the handler to node for the given path
-func (n *node) add(path, fullPath string, handlers []Handler) (*node, error) {
+func (n *node) add(path, fullPath string, handlers []Handler, constraints constraintResolver) (*node, error) {
 	if len(path) == 0 {
 		return n.setHandler(handlers, fullPath)
 	}
@@ -314,10 +363,10 @@ func (n *node) add(path, fullPath string, handlers []Handler) (*node, error) {
 			}
 
 			if len(path) > i {
-				return child.add(path[i:], fullPath, handlers)
+				return child.add(path[i:], fullPath, handlers, constraints)
 			}
 		case param:
-			wp := findWildPath(path, fullPath)
+			wp := findWildPath(path, fullPath, constraints)
 
 			isParam := wp.start == 0 && wp.pType == param
 			hasHandler := child.handlers != nil || handlers == nil
@@ -328,15 +377,17 @@ func (n *node) add(path, fullPath string, handlers []Handler) (*node, error) {
 					return child, newRadixError(errSetHandler, fullPath)
 				}
 
-				return nil, child.wildPathConflict(path, fullPath)
+				// A different param in the same segment is tried in the
+				// order given by Less
+				return n.insert(path, fullPath, handlers, constraints)
 			}
 
 			if len(path) > i {
 				if child.path == wp.path {
-					return child.add(path[i:], fullPath, handlers)
+					return child.add(path[i:], fullPath, handlers, constraints)
 				}
 
-				return n.insert(path, fullPath, handlers)
+				return n.insert(path, fullPath, handlers, constraints)
 			}
 		}
 
@@ -347,10 +398,10 @@ func (n *node) add(path, fullPath string, handlers []Handler) (*node, error) {
 		return child.setHandler(handlers, fullPath)
 	}
 
-	return n.insert(path, fullPath, handlers)
+	return n.insert(path, fullPath, handlers, constraints)
 }
 
-func (n *node) getFromChild(path string) ([]Handler, map[string]string, bool) {
+func (n *node) getFromChild(path string, params *pathParams) ([]Handler, bool) {
 	for _, child := range n.children {
 		switch child.nType {
 		case static:
@@ -361,60 +412,53 @@ func (n *node) getFromChild(path string) ([]Handler, map[string]string, bool) {
 				if path[:len(child.path)] != child.path {
 					continue
 				}
-				h, params, tsr := child.getFromChild(path[len(child.path):])
+				h, tsr := child.getFromChild(path[len(child.path):], params)
 				if h != nil || tsr {
-					return h, params, tsr
+					return h, tsr
 				}
 			} else if path == child.path {
 				switch {
 				case child.tsr:
-					return nil, nil, true
+					return nil, true
 				case child.handlers != nil:
-					return child.handlers, nil, false
+					return child.handlers, false
 				case child.wildcard != nil:
-					params := map[string]string{
-						child.wildcard.paramKey: "",
-					}
-					return child.wildcard.handlers, params, false
+					*params = append(*params, pathParam{key: child.wildcard.paramKey})
+					return child.wildcard.handlers, false
 				}
-				return nil, nil, false
+				return nil, false
 			}
 
 		case param:
 			end := segmentEndIndex(path, false)
-			paramVal := path[:end]
-			values := []string{gstrings.Copy(paramVal)}
 
+			// The parameters are appended before trying the children, and
+			// removed if the path doesn't match
+			start := len(*params)
 			if child.paramRegex != nil {
-				end, values = child.findEndIndexAndValues(paramVal)
-				if end == -1 {
+				if end = child.matchParams(path[:end], params); end == -1 {
 					continue
 				}
+			} else {
+				*params = append(*params, pathParam{key: child.paramKeys[0], value: path[:end]})
 			}
 
 			if len(path) > end {
-				h, params, tsr := child.getFromChild(path[end:])
+				h, tsr := child.getFromChild(path[end:], params)
 				if h != nil {
-					if params == nil {
-						params = make(map[string]string)
-					}
-					for i, key := range child.paramKeys {
-						params[key] = values[i]
-					}
-					return h, params, false
-				} else if tsr {
-					return nil, nil, true
+					return h, false
+				}
+				*params = (*params)[:start]
+				if tsr {
+					return nil, true
 				}
 			} else if len(path) == end {
 				if child.handlers != nil {
-					params := make(map[string]string)
-					for i, key := range child.paramKeys {
-						params[key] = values[i]
-					}
-					return child.handlers, params, false
+					return child.handlers, false
 				}
+				*params = (*params)[:start]
 				if child.tsr {
-					return nil, nil, true
+					return nil, true
 				}
 				// Try another child
 				continue
@@ -426,13 +470,11 @@ func (n *node) getFromChild(path string) ([]Handler, map[string]string, bool) {
 	}
 
 	if n.wildcard != nil {
-		params := map[string]string{
-			n.wildcard.paramKey: gstrings.Copy(path),
-		}
-		return n.wildcard.handlers, params, false
+		*params = append(*params, pathParam{key: n.wildcard.paramKey, value: path})
+		return n.wildcard.handlers, false
 	}
 
-	return nil, nil, false
+	return nil, false
 }
 
 func (n *node) find(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
@@ -487,8 +529,8 @@ func (n *node) findFromChild(path string, buf *bytebufferpool.ByteBuffer) (bool,
 			end := segmentEndIndex(path, false)
 
 			if child.paramRegex != nil {
-				end, _ = child.findEndIndexAndValues(path[:end])
-				if end == -1 {
+				var params pathParams
+				if end = child.matchParams(path[:end], &params); end == -1 {
 					continue
 				}
 			}
@@ -535,7 +577,7 @@ func (n *node) sort() {
 		child.sort()
 	}
 
-	sort.Sort(n)
+	sort.Stable(n)
 }
 
 // Len returns the total number of children the node has
@@ -548,15 +590,34 @@ func (n *node) Swap(i, j int) {
 	n.children[i], n.children[j] = n.children[j], n.children[i]
 }
 
-// Less checks if the node 'i' has less priority than the node 'j'
+// Less checks if the node 'i' is tried before the node 'j'.
+//
+// Static nodes are tried first, then params with a regular expression and
+// then plain params. Wildcards are stored apart and tried last. Params of the
+// same kind are tried in the order they were added, static nodes with more
+// children first.
 func (n *node) Less(i, j int) bool {
-	if n.children[i].nType < n.children[j].nType {
-		return true
-	} else if n.children[i].nType > n.children[j].nType {
-		return false
+	pi, pj := n.children[i].priority(), n.children[j].priority()
+	if pi != pj {
+		return pi < pj
+	}
+
+	if n.children[i].nType == static {
+		return len(n.children[i].children) > len(n.children[j].children)
 	}
+	return false
+}
 
-	return len(n.children[i].children) > len(n.children[j].children)
+// priority returns the rank of the node among its siblings, lower first.
+func (n *node) priority() int {
+	switch {
+	case n.nType != param:
+		return 0
+	case n.paramRegex != nil:
+		return 1
+	default:
+		return 2
+	}
 }
 
 // tree is a routes storage
@@ -565,6 +626,9 @@ type tree struct {
 
 	// If enabled, the node handler could be updated
 	Mutable bool
+
+	// Resolves the constraints of parameters, resolveConstraint if nil
+	constraints constraintResolver
 }
 
 // New returns an empty routes storage
@@ -597,7 +661,12 @@ func (t *tree) Add(path string, handlers []Handler) {
 		path = path[i:]
 	}
 
-	n, err := t.root.add(path, fullPath, handlers)
+	constraints := t.constraints
+	if constraints == nil {
+		constraints = resolveConstraint
+	}
+
+	n, err := t.root.add(path, fullPath, handlers, constraints)
 	if err != nil {
 		var radixErr radixError
 
@@ -624,32 +693,48 @@ func (t *tree) Add(path string, handlers []Handler) {
 	t.root.sort()
 }
 
+// Set replaces the handlers of a path added before.
+func (t *tree) Set(path string, handlers []Handler) {
+	mutable := t.Mutable
+	t.Mutable = true
+	defer func() { t.Mutable = mutable }()
+
+	t.Add(path, handlers)
+}
+
 // Get returns the handler(s) registered with the given path.
 // It also returns any route parameters as map[string]string and a bool indicating a TSR (trailing slash redirect).
 func (t *tree) Get(path string) ([]Handler, map[string]string, bool) {
+	var params pathParams
+	handlers, tsr := t.lookup(path, &params)
+	return handlers, params.toMap(), tsr
+}
+
+// lookup returns the handler(s) registered with the given path and a bool
+// indicating a TSR (trailing slash redirect). The route parameters are
+// appended to params, which is left unchanged if no handler is found.
+func (t *tree) lookup(path string, params *pathParams) ([]Handler, bool) {
 	if len(path) > len(t.root.path) {
 		if path[:len(t.root.path)] != t.root.path {
-			return nil, nil, false
+			return nil, false
 		}
 
 		path = path[len(t.root.path):]
-		return t.root.getFromChild(path)
+		return t.root.getFromChild(path, params)
 
 	} else if path == t.root.path {
 		switch {
 		case t.root.tsr:
-			return nil, nil, true
+			return nil, true
 		case t.root.handlers != nil:
-			return t.root.handlers, nil, false
+			return t.root.handlers, false
 		case t.root.wildcard != nil:
-			params := map[string]string{
-				t.root.wildcard.paramKey: "",
-			}
-			return t.root.wildcard.handlers, params, false
+			*params = append(*params, pathParam{key: t.root.wildcard.paramKey})
+			return t.root.wildcard.handlers, false
 		}
 	}
 
-	return nil, nil, false
+	return nil, false
 }
 
 // FindCaseInsensitivePath makes a case-insensitive lookup of the given path
@@ -1,6 +1,7 @@
 package nexora
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/valyala/bytebufferpool"
@@ -230,6 +231,26 @@ func TestTree_MultiParamRoute(t *testing.T) {
 	}
 }
 
+func TestTree_LookupParams(t *testing.T) {
+	tree := &tree{root: newNode("/")}
+	tree.Add("/users/{id:[0-9]+}/posts", []Handler{h("posts")})
+	tree.Add("/users/{name}/{tab}", []Handler{h("tab")})
+
+	// The value of id is removed when /posts doesn't match
+	params := make(pathParams, 0, 4)
+	if handlers, _ := tree.lookup("/users/42/likes", &params); handlers == nil {
+		t.Fatal("expected handler for /users/42/likes")
+	}
+	if want := (pathParams{{key: "name", value: "42"}, {key: "tab", value: "likes"}}); !reflect.DeepEqual(params, want) {
+		t.Errorf("params = %v, want %v", params, want)
+	}
+
+	params = params[:0]
+	if handlers, _ := tree.lookup("/users/42/likes/all", &params); handlers != nil || len(params) != 0 {
+		t.Errorf("lookup(/users/42/likes/all) = %v, params %v", handlers, params)
+	}
+}
+
 func TestTree_ConflictPanics(t *testing.T) {
 	defer func() {
 		if recover() == nil {
@@ -313,3 +334,45 @@ func BenchmarkTree_WildcardRoute(b *testing.B) {
 		tree.Get("/assets/css/main.css")
 	}
 }
+
+// paramBenchmarks are the routes of the benchmarks comparing the map returned
+// by Get with the reused slice of lookup.
+var paramBenchmarks = []struct {
+	name, route, path string
+}{
+	{"Static", "/home", "/home"},
+	{"Param", "/user/{id}", "/user/123"},
+	{"MultiParam", "/blog/{year}/{month}/{slug}", "/blog/2024/05/zeno-rocks"},
+	{"Regex", "/order/{oid:[0-9]+}", "/order/456"},
+	{"Wildcard", "/assets/{filepath:*}", "/assets/css/main.css"},
+}
+
+func BenchmarkTree_GetMap(b *testing.B) {
+	for _, bm := range paramBenchmarks {
+		tree := &tree{root: newNode("/")}
+		tree.Add(bm.route, []Handler{h(bm.name)})
+
+		b.Run(bm.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				tree.Get(bm.path)
+			}
+		})
+	}
+}
+
+func BenchmarkTree_LookupSlice(b *testing.B) {
+	for _, bm := range paramBenchmarks {
+		tree := &tree{root: newNode("/")}
+		tree.Add(bm.route, []Handler{h(bm.name)})
+		params := make(pathParams, 0, 4)
+
+		b.Run(bm.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				params = params[:0]
+				tree.lookup(bm.path, &params)
+			}
+		})
+	}
+}
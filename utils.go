@@ -71,7 +71,7 @@ func segmentEndIndex(path string, includeTSR bool) int {
 
 // findWildPath search for a wild path segment and check the name for invalid characters.
 // Returns -1 as index, if no param/wildcard was found.
-func findWildPath(path string, fullPath string) *wildPath {
+func findWildPath(path string, fullPath string, constraints constraintResolver) *wildPath {
 	// Find start
 	for start, c := range []byte(path) {
 		// A wildcard starts with ':' (param) or '*' (wildcard)
@@ -104,6 +104,8 @@ func findWildPath(path string, fullPath string) *wildPath {
 					panic("the wildcards must be separated by at least 1 char")
 				}
 
+				wp.parsers = []func(string) (any, error){nil}
+
 				sn := strings.SplitN(wp.keys[0], ":", 2)
 				if len(sn) > 1 {
 					wp.keys = []string{sn[0]}
@@ -113,6 +115,16 @@ func findWildPath(path string, fullPath string) *wildPath {
 						wp.pattern = pattern
 						wp.pType = wildcard
 					} else {
+						// Typed constraints are matched by their pattern, and
+						// the matched values are parsed after matching
+						if c, ok := constraints(pattern); ok {
+							pattern = c.pattern
+							if pattern == "" {
+								pattern = `[^/]+`
+							}
+							wp.parsers[0] = c.parse
+						}
+
 						wp.pattern = "(" + pattern + ")"
 						wp.regex = regexp.MustCompile(wp.pattern)
 					}
@@ -135,7 +147,7 @@ func findWildPath(path string, fullPath string) *wildPath {
 
 				if len(path) > 0 {
 					// Rebuild the wildpath with the prefix
-					wp2 := findWildPath(path, fullPath)
+					wp2 := findWildPath(path, fullPath, constraints)
 					if wp2 != nil {
 						prefix := path[:wp2.start]
 
@@ -143,6 +155,7 @@ func findWildPath(path string, fullPath string) *wildPath {
 						wp.path += prefix + wp2.path
 						wp.pattern += prefix + wp2.pattern
 						wp.keys = append(wp.keys, wp2.keys...)
+						wp.parsers = append(wp.parsers, wp2.parsers...)
 					} else {
 						wp.path += path
 						wp.pattern += path
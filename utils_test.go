@@ -85,7 +85,7 @@ func Test_findWildPath(t *testing.T) {
 	for _, test := range tests {
 		fullPath := test.path
 
-		result := findWildPath(test.path, fullPath)
+		result := findWildPath(test.path, fullPath, resolveConstraint)
 
 		if result.path != test.want.path {
 			t.Errorf("wildPath.path == %s, want %s", result.path, test.want.path)
@@ -176,7 +176,7 @@ func Test_findWildPathConflict(t *testing.T) {
 		fullPath := test.path
 
 		err := catchPanic(func() {
-			findWildPath(test.path, fullPath)
+			findWildPath(test.path, fullPath, resolveConstraint)
 		})
 
 		if test.wantErr != (err != nil) {
@@ -0,0 +1,301 @@
+package nexora
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// FieldError describes a single struct field that failed validation.
+type FieldError struct {
+	Field   string `json:"field"`           // Dotted path of the field, using json names when present (e.g. "items[0].name")
+	Rule    string `json:"rule"`            // Name of the failed rule (e.g. "required", "min")
+	Param   string `json:"param,omitempty"` // Parameter of the rule, if any (e.g. "3" for min=3)
+	Message string `json:"message"`         // Human-readable message
+}
+
+// Error implements the error interface.
+func (e FieldError) Error() string {
+	return e.Message
+}
+
+// ValidationErrors is returned by Validate when one or more fields fail validation.
+// The default error handler renders it as a 422 response with a JSON list of field errors.
+type ValidationErrors []FieldError
+
+// Error implements the error interface.
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, fe := range e {
+		msgs[i] = fe.Message
+	}
+	return "validation failed: " + strings.Join(msgs, "; ")
+}
+
+// validatorRegexps caches compiled rule patterns.
+var validatorRegexps sync.Map
+
+// Validate validates the struct pointed to by v using the `validate` struct tag.
+// Rules are separated by commas:
+//
+//	required      field must not be the zero value (pointers must be non-nil)
+//	omitempty     skip all other rules when the field is the zero value
+//	min=N         minimum value for numbers, minimum length for strings, slices and maps
+//	max=N         maximum value for numbers, maximum length for strings, slices and maps
+//	len=N         exact length for strings, slices and maps
+//	oneof=a b c   value must be one of the space separated options
+//	regex=EXPR    value must match EXPR; must be the last rule in the tag
+//
+// Every parameter type known to route constraints can also be used as a rule,
+// for example email, uuid, alpha, alnum, slug, hex, ip or date.
+//
+// Nested structs and slices of structs are validated recursively.
+// A ValidationErrors value is returned when one or more fields are invalid.
+//
+// Example:
+//
+//	type SignUp struct {
+//	    Email string   `json:"email" validate:"required,email"`
+//	    Name  string   `json:"name" validate:"required,min=2,max=64"`
+//	    Role  string   `json:"role" validate:"oneof=admin user"`
+//	    Tags  []string `json:"tags" validate:"max=5"`
+//	}
+func Validate(v any) error {
+	var errs ValidationErrors
+	validateValue(reflect.ValueOf(v), "", &errs)
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// Validate validates v using the Validator configured on Nexora, or the
+// package level Validate function if none is set.
+func (c *Context) Validate(v any) error {
+	if c.nexora != nil && c.nexora.Validator != nil {
+		return c.nexora.Validator(v)
+	}
+	return Validate(v)
+}
+
+func validateValue(rv reflect.Value, path string, errs *ValidationErrors) {
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return
+		}
+		rv = rv.Elem()
+	}
+
+	switch rv.Kind() {
+	case reflect.Struct:
+		validateStruct(rv, path, errs)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			validateValue(rv.Index(i), fmt.Sprintf("%s[%d]", path, i), errs)
+		}
+	}
+}
+
+func validateStruct(rv reflect.Value, path string, errs *ValidationErrors) {
+	rt := rv.Type()
+
+	for i := 0; i < rt.NumField(); i++ {
+		sf := rt.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		fv := rv.Field(i)
+		name := fieldName(sf)
+		if sf.Anonymous && sf.Tag.Get("json") == "" {
+			// Embedded structs are validated as if their fields were declared inline.
+			validateValue(fv, path, errs)
+			continue
+		}
+		if path != "" {
+			name = path + "." + name
+		}
+
+		if tag := sf.Tag.Get("validate"); tag != "" && tag != "-" {
+			if !validateField(fv, name, tag, errs) {
+				continue
+			}
+		}
+
+		validateValue(fv, name, errs)
+	}
+}
+
+// fieldName returns the json name of the field, falling back to the Go name.
+func fieldName(sf reflect.StructField) string {
+	if name, _, _ := strings.Cut(sf.Tag.Get("json"), ","); name != "" && name != "-" {
+		return name
+	}
+	return sf.Name
+}
+
+// validateField applies the rules in tag to fv. It reports whether the field
+// is valid so far, in which case nested values are validated as well.
+func validateField(fv reflect.Value, name, tag string, errs *ValidationErrors) bool {
+	rules := splitRules(tag)
+
+	if fv.Kind() == reflect.Pointer {
+		if fv.IsNil() {
+			for _, rule := range rules {
+				if rule == "required" {
+					errs.add(name, "required", "", name+" is required")
+					return false
+				}
+			}
+			return true
+		}
+		fv = fv.Elem()
+	}
+
+	zero := fv.IsZero()
+	for _, rule := range rules {
+		if rule == "omitempty" && zero {
+			return true
+		}
+	}
+
+	valid := true
+	for _, rule := range rules {
+		rule, param, _ := strings.Cut(rule, "=")
+
+		switch rule {
+		case "omitempty":
+		case "required":
+			if zero {
+				errs.add(name, rule, "", name+" is required")
+				return false
+			}
+		case "min", "max", "len":
+			if fe, ok := checkBound(fv, name, rule, param); !ok {
+				*errs = append(*errs, fe)
+				valid = false
+			}
+		case "oneof":
+			value := fmt.Sprint(fv.Interface())
+			options := strings.Fields(param)
+			found := false
+			for _, option := range options {
+				if option == value {
+					found = true
+					break
+				}
+			}
+			if !found {
+				errs.add(name, rule, param, fmt.Sprintf("%s must be one of [%s]", name, strings.Join(options, ", ")))
+				valid = false
+			}
+		case "regex":
+			if fv.Kind() != reflect.String || !compileRule(param, false).MatchString(fv.String()) {
+				errs.add(name, rule, param, fmt.Sprintf("%s must match the pattern %s", name, param))
+				valid = false
+			}
+		default:
+			if _, ok := resolveConstraint(rule); !ok {
+				panicf("nexora: unknown validation rule %q on field %s", rule, name)
+			}
+			if fv.Kind() != reflect.String || !matchConstraint(resolveConstraint, rule, fv.String()) {
+				errs.add(name, rule, "", fmt.Sprintf("%s must be a valid %s", name, rule))
+				valid = false
+			}
+		}
+	}
+
+	return valid
+}
+
+// splitRules splits a validate tag into its rules.
+// A regex rule consumes the remainder of the tag, so it may contain commas.
+func splitRules(tag string) []string {
+	var rules []string
+	for tag != "" {
+		if strings.HasPrefix(tag, "regex=") {
+			return append(rules, tag)
+		}
+		rule, rest, _ := strings.Cut(tag, ",")
+		if rule = strings.TrimSpace(rule); rule != "" {
+			rules = append(rules, rule)
+		}
+		tag = rest
+	}
+	return rules
+}
+
+// checkBound checks a min, max or len rule against fv.
+func checkBound(fv reflect.Value, name, rule, param string) (FieldError, bool) {
+	limit, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		panicf("nexora: invalid %s parameter %q on field %s", rule, param, name)
+	}
+
+	var (
+		actual float64
+		unit   string
+	)
+
+	switch fv.Kind() {
+	case reflect.String:
+		actual, unit = float64(utf8.RuneCountInString(fv.String())), " characters"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		actual, unit = float64(fv.Len()), " items"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		actual = float64(fv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		actual = float64(fv.Uint())
+	case reflect.Float32, reflect.Float64:
+		actual = fv.Float()
+	default:
+		panicf("nexora: %s rule is not supported on field %s of type %s", rule, name, fv.Type())
+	}
+
+	fe := FieldError{Field: name, Rule: rule, Param: param}
+	isNumber := unit == ""
+
+	switch {
+	case rule == "min" && actual < limit:
+		if isNumber {
+			fe.Message = fmt.Sprintf("%s must be at least %s", name, param)
+		} else {
+			fe.Message = fmt.Sprintf("%s must contain at least %s%s", name, param, unit)
+		}
+	case rule == "max" && actual > limit:
+		if isNumber {
+			fe.Message = fmt.Sprintf("%s must be at most %s", name, param)
+		} else {
+			fe.Message = fmt.Sprintf("%s must contain at most %s%s", name, param, unit)
+		}
+	case rule == "len" && actual != limit:
+		fe.Message = fmt.Sprintf("%s must contain exactly %s%s", name, param, unit)
+	default:
+		return fe, true
+	}
+
+	return fe, false
+}
+
+// compileRule compiles and caches pattern. Anchored patterns must match the
+// whole value, as is the case for the patterns of route constraints.
+func compileRule(pattern string, anchored bool) *regexp.Regexp {
+	key := pattern
+	if anchored {
+		key = "^(?:" + pattern + ")$"
+	}
+	if re, ok := validatorRegexps.Load(key); ok {
+		return re.(*regexp.Regexp)
+	}
+	re := regexp.MustCompile(key)
+	validatorRegexps.Store(key, re)
+	return re
+}
+
+func (e *ValidationErrors) add(field, rule, param, message string) {
+	*e = append(*e, FieldError{Field: field, Rule: rule, Param: param, Message: message})
+}
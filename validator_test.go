@@ -0,0 +1,195 @@
+package nexora
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type validateAddress struct {
+	City string `json:"city" validate:"required"`
+	Zip  string `json:"zip" validate:"len=5"`
+}
+
+type validateItem struct {
+	SKU string `json:"sku" validate:"required,regex=^[A-Z]{3}-\\d+$"`
+	Qty int    `json:"qty" validate:"min=1,max=10"`
+}
+
+type validateOrder struct {
+	ID       string          `json:"id" validate:"required,uuid"`
+	Email    string          `json:"email" validate:"required,email"`
+	Status   string          `json:"status" validate:"oneof=new paid shipped"`
+	Note     string          `json:"note" validate:"omitempty,min=3"`
+	Code     string          `json:"code" validate:"omitempty,alpha"`
+	Priority string          `json:"priority" validate:"omitempty,uint8"`
+	Tags     []string        `json:"tags" validate:"max=2"`
+	Coupon   *string         `json:"coupon" validate:"required"`
+	Address  validateAddress `json:"address"`
+	Items    []validateItem  `json:"items" validate:"min=1"`
+	Internal string
+}
+
+func validOrder() validateOrder {
+	coupon := "FREE"
+	return validateOrder{
+		ID:      "123e4567-e89b-12d3-a456-426614174000",
+		Email:   "jane@example.com",
+		Status:  "paid",
+		Coupon:  &coupon,
+		Address: validateAddress{City: "Pune", Zip: "41100"},
+		Items:   []validateItem{{SKU: "ABC-1", Qty: 2}},
+	}
+}
+
+func TestValidate_Valid(t *testing.T) {
+	o := validOrder()
+	if err := Validate(&o); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+}
+
+func TestValidate_Rules(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(o *validateOrder)
+		field  string
+		rule   string
+	}{
+		{"required", func(o *validateOrder) { o.ID = "" }, "id", "required"},
+		{"uuid", func(o *validateOrder) { o.ID = "nope" }, "id", "uuid"},
+		{"email", func(o *validateOrder) { o.Email = "jane" }, "email", "email"},
+		{"oneof", func(o *validateOrder) { o.Status = "lost" }, "status", "oneof"},
+		{"omitempty min", func(o *validateOrder) { o.Note = "ok" }, "note", "min"},
+		{"constraint type", func(o *validateOrder) { o.Code = "abc1" }, "code", "alpha"},
+		{"typed constraint", func(o *validateOrder) { o.Priority = "256" }, "priority", "uint8"},
+		{"slice max", func(o *validateOrder) { o.Tags = []string{"a", "b", "c"} }, "tags", "max"},
+		{"nil pointer", func(o *validateOrder) { o.Coupon = nil }, "coupon", "required"},
+		{"nested struct", func(o *validateOrder) { o.Address.City = "" }, "address.city", "required"},
+		{"nested len", func(o *validateOrder) { o.Address.Zip = "123" }, "address.zip", "len"},
+		{"slice of structs", func(o *validateOrder) { o.Items[0].Qty = 11 }, "items[0].qty", "max"},
+		{"regex", func(o *validateOrder) { o.Items[0].SKU = "abc-1" }, "items[0].sku", "regex"},
+		{"empty slice", func(o *validateOrder) { o.Items = nil }, "items", "min"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := validOrder()
+			o.Items = append([]validateItem(nil), o.Items...)
+			tt.mutate(&o)
+
+			err := Validate(&o)
+
+			var errs ValidationErrors
+			if !errors.As(err, &errs) {
+				t.Fatalf("Validate() error = %v, want ValidationErrors", err)
+			}
+			if len(errs) != 1 || errs[0].Field != tt.field || errs[0].Rule != tt.rule {
+				t.Errorf("Validate() = %+v, want field %q rule %q", errs, tt.field, tt.rule)
+			}
+		})
+	}
+}
+
+func TestValidate_UnknownRulePanics(t *testing.T) {
+	type bad struct {
+		Name string `validate:"shiny"`
+	}
+	if err := catchPanic(func() { Validate(&bad{}) }); err == nil {
+		t.Error("expected panic for unknown rule")
+	}
+}
+
+func TestBind_ValidationRenderedAs422(t *testing.T) {
+	type signUp struct {
+		Name  string `json:"name" validate:"required,min=2"`
+		Email string `json:"email" validate:"required,email"`
+	}
+
+	n := New()
+	n.Post("/signup", func(c *Context) error {
+		var in signUp
+		if err := c.Bind(&in); err != nil {
+			return err
+		}
+		return c.SendString("ok")
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/signup", strings.NewReader(`{"name":"a"}`))
+	req.Header.Set(HeaderContentType, MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	n.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusUnprocessableEntity)
+	}
+	if ct := rec.Header().Get(HeaderContentType); ct != MIMEApplicationJSONCharsetUTF8 {
+		t.Errorf("Content-Type = %q", ct)
+	}
+
+	var body struct {
+		Errors []FieldError `json:"errors"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid JSON body %q: %v", rec.Body.String(), err)
+	}
+	if len(body.Errors) != 2 || body.Errors[0].Field != "name" || body.Errors[1].Field != "email" {
+		t.Errorf("errors = %+v", body.Errors)
+	}
+}
+
+func TestContext_BindSeveralSources(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPut, "/users/7", strings.NewReader(`{"name":"alice"}`))
+	req.Header.Set(HeaderContentType, MIMEApplicationJSON)
+
+	ctx := newContext(New())
+	ctx.init(req, httptest.NewRecorder())
+	ctx.params = pathParams{{key: "id", value: "7"}}
+
+	var dst struct {
+		ID   int    `param:"id" validate:"required"`
+		Name string `json:"name" validate:"required"`
+	}
+
+	// The single source binders don't validate the fields bound by the others
+	if err := ctx.BindParams(&dst); err != nil {
+		t.Fatalf("BindParams() error = %v", err)
+	}
+	if err := ctx.BindJSON(&dst); err != nil {
+		t.Fatalf("BindJSON() error = %v", err)
+	}
+	if err := ctx.Validate(&dst); err != nil || dst.ID != 7 || dst.Name != "alice" {
+		t.Errorf("Validate() = %v with %+v", err, dst)
+	}
+
+	dst.Name = ""
+	var errs ValidationErrors
+	if err := ctx.Validate(&dst); !errors.As(err, &errs) || len(errs) != 1 || errs[0].Field != "name" {
+		t.Errorf("Validate() error = %v, want a required name", err)
+	}
+}
+
+func TestContext_ValidateCustomValidator(t *testing.T) {
+	n := New()
+	called := false
+	n.Validator = func(v any) error {
+		called = true
+		return nil
+	}
+
+	ctx := newContext(n)
+	ctx.init(httptest.NewRequest(http.MethodGet, "/?name=x", nil), httptest.NewRecorder())
+
+	var dst struct {
+		Name string `query:"name" validate:"min=5"`
+	}
+	if err := ctx.Bind(&dst); err != nil {
+		t.Fatalf("Bind() error = %v", err)
+	}
+	if !called {
+		t.Error("custom Validator was not called")
+	}
+}
@@ -0,0 +1,667 @@
+package nexora
+
+import (
+	"bufio"
+	"bytes"
+	"compress/flate"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// WebSocket message types, as defined in RFC 6455 section 11.8.
+const (
+	WebSocketText   = 1
+	WebSocketBinary = 2
+	WebSocketClose  = 8
+	WebSocketPing   = 9
+	WebSocketPong   = 10
+)
+
+// WebSocket close codes, as defined in RFC 6455 section 7.4.1.
+const (
+	WebSocketCloseNormal          = 1000
+	WebSocketCloseGoingAway       = 1001
+	WebSocketCloseProtocolError   = 1002
+	WebSocketCloseUnsupportedData = 1003
+	WebSocketCloseNoStatus        = 1005
+	WebSocketCloseInvalidPayload  = 1007
+	WebSocketClosePolicyViolation = 1008
+	WebSocketCloseMessageTooBig   = 1009
+	WebSocketCloseInternalError   = 1011
+)
+
+// DefaultWebSocketReadLimit is the default maximum size of a message read
+// from a WebSocket connection.
+const DefaultWebSocketReadLimit = 4 << 20
+
+const (
+	websocketGUID         = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+	websocketContinuation = 0
+
+	// deflateTail completes a compressed message: the sync flush marker that
+	// is removed by the sender, followed by an empty final block.
+	deflateTail = "\x00\x00\xff\xff\x01\x00\x00\xff\xff"
+)
+
+// ErrWebSocketClosed is returned when writing to a WebSocket connection after
+// a close frame has been sent.
+var ErrWebSocketClosed = errors.New("nexora: websocket connection closed")
+
+// WebSocketCloseError is returned by the read methods of WebSocketConn when
+// the connection has been closed, either by the client with a close frame or
+// by the server because the client violated the protocol.
+type WebSocketCloseError struct {
+	Code   int    // Close code, e.g. WebSocketCloseNormal
+	Reason string // Reason sent with the close frame, if any
+}
+
+// Error implements the error interface.
+func (e *WebSocketCloseError) Error() string {
+	if e.Reason == "" {
+		return "websocket: close " + strconv.Itoa(e.Code)
+	}
+	return "websocket: close " + strconv.Itoa(e.Code) + ": " + e.Reason
+}
+
+// WebSocketConfig configures the WebSocket upgrade performed by Context.WebSocket.
+type WebSocketConfig struct {
+	// Subprotocols supported by the server. The first protocol requested by
+	// the client that is in this list is selected.
+	Subprotocols []string
+
+	// Function deciding whether the Origin of the request is allowed. If it
+	// is not set, requests without an Origin header and requests whose
+	// Origin host equals the Host header are allowed.
+	CheckOrigin func(c *Context) bool
+
+	// Maximum size in bytes of a message read from the connection, after
+	// decompression. Defaults to DefaultWebSocketReadLimit.
+	ReadLimit int64
+
+	// If enabled, the permessage-deflate extension (RFC 7692) is negotiated
+	// with clients that offer it, and messages are sent compressed.
+	EnableCompression bool
+}
+
+// WebSocketConn is a WebSocket connection upgraded by Context.WebSocket.
+//
+// One goroutine may read from the connection while others write to it, as
+// writes are serialized. The connection is not tied to the Context and can be
+// used after the handler returns.
+type WebSocketConn struct {
+	conn        net.Conn
+	br          *bufio.Reader
+	bw          *bufio.Writer
+	subprotocol string
+	compress    bool
+	readLimit   int64
+
+	writeMu   sync.Mutex
+	closeSent bool
+	closeOnce sync.Once
+
+	pingHandler, pongHandler func(data []byte) error
+}
+
+// WebSocket upgrades the connection to the WebSocket protocol (RFC 6455).
+// As it is a regular handler method, route params and middleware such as
+// authentication run before the upgrade.
+//
+// An *HTTPError is returned if the request is not a valid WebSocket handshake
+// (426 or 400) or its origin is not allowed (403). In that case nothing has
+// been written, so the error is sent to the client as usual.
+//
+// Example:
+//
+//	app.Get("/rooms/{room}/ws", func(c *nexora.Context) error {
+//	    ws, err := c.WebSocket(nexora.WebSocketConfig{EnableCompression: true})
+//	    if err != nil {
+//	        return err
+//	    }
+//	    defer ws.Close()
+//
+//	    for {
+//	        typ, msg, err := ws.ReadMessage()
+//	        if err != nil {
+//	            return nil
+//	        }
+//	        if err := ws.WriteMessage(typ, msg); err != nil {
+//	            return nil
+//	        }
+//	    }
+//	})
+func (c *Context) WebSocket(config ...WebSocketConfig) (*WebSocketConn, error) {
+	var cfg WebSocketConfig
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+	if cfg.ReadLimit <= 0 {
+		cfg.ReadLimit = DefaultWebSocketReadLimit
+	}
+	if cfg.CheckOrigin == nil {
+		cfg.CheckOrigin = sameOrigin
+	}
+
+	header := c.request.Header
+	switch {
+	case c.request.Method != MethodGet:
+		return nil, ErrMethodNotAllowed
+	case !headerHasToken(header, HeaderConnection, "upgrade"), !headerHasToken(header, HeaderUpgrade, "websocket"):
+		c.SetHeader(HeaderUpgrade, "websocket")
+		return nil, ErrUpgradeRequired
+	case header.Get(HeaderSecWebSocketVersion) != "13":
+		c.SetHeader(HeaderSecWebSocketVersion, "13")
+		return nil, ErrUpgradeRequired
+	}
+
+	key := header.Get(HeaderSecWebSocketKey)
+	if decoded, err := base64.StdEncoding.DecodeString(key); err != nil || len(decoded) != 16 {
+		return nil, NewHTTPError(StatusBadRequest, "invalid Sec-WebSocket-Key header")
+	}
+
+	if !cfg.CheckOrigin(c) {
+		return nil, ErrForbidden
+	}
+
+	subprotocol := selectSubprotocol(header, cfg.Subprotocols)
+	compress := cfg.EnableCompression && offersDeflate(header)
+
+	conn, brw, err := c.writer.hijack()
+	if err != nil {
+		return nil, err
+	}
+
+	// Headers set by middleware, such as cookies, are sent with the handshake.
+	extra := c.writer.Header().Clone()
+	for _, key := range []string{HeaderUpgrade, HeaderConnection, HeaderSecWebSocketAccept, HeaderSecWebSocketProtocol, HeaderSecWebSocketExtensions, HeaderContentLength, HeaderContentType} {
+		extra.Del(key)
+	}
+
+	brw.WriteString("HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n")
+	brw.WriteString(HeaderSecWebSocketAccept + ": " + websocketAccept(key) + "\r\n")
+	if subprotocol != "" {
+		brw.WriteString(HeaderSecWebSocketProtocol + ": " + subprotocol + "\r\n")
+	}
+	if compress {
+		brw.WriteString(HeaderSecWebSocketExtensions + ": permessage-deflate; server_no_context_takeover; client_no_context_takeover\r\n")
+	}
+	extra.Write(brw)
+	brw.WriteString("\r\n")
+	if err := brw.Flush(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	// Clear the deadlines set by the HTTP server.
+	conn.SetDeadline(time.Time{})
+
+	return &WebSocketConn{
+		conn:        conn,
+		br:          brw.Reader,
+		bw:          brw.Writer,
+		subprotocol: subprotocol,
+		compress:    compress,
+		readLimit:   cfg.ReadLimit,
+	}, nil
+}
+
+// websocketAccept computes the Sec-WebSocket-Accept header for key.
+func websocketAccept(key string) string {
+	sum := sha1.Sum([]byte(key + websocketGUID))
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// headerHasToken reports whether the comma-separated header contains token,
+// compared case-insensitively.
+func headerHasToken(header http.Header, name, token string) bool {
+	for _, value := range header.Values(name) {
+		for _, v := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(v), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// sameOrigin allows requests without an Origin header and requests whose
+// Origin host equals the Host header.
+func sameOrigin(c *Context) bool {
+	origin := c.GetHeader(HeaderOrigin)
+	if origin == "" {
+		return true
+	}
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(u.Host, c.request.Host)
+}
+
+// selectSubprotocol returns the first subprotocol requested by the client that
+// is supported by the server.
+func selectSubprotocol(header http.Header, supported []string) string {
+	for _, value := range header.Values(HeaderSecWebSocketProtocol) {
+		for _, requested := range strings.Split(value, ",") {
+			requested = strings.TrimSpace(requested)
+			for _, protocol := range supported {
+				if protocol == requested {
+					return protocol
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// offersDeflate reports whether the client offers permessage-deflate with
+// parameters the server can accept. Limiting the window of the server is not
+// supported by compress/flate, so such offers are declined.
+func offersDeflate(header http.Header) bool {
+	for _, value := range header.Values(HeaderSecWebSocketExtensions) {
+		for _, offer := range strings.Split(value, ",") {
+			name, params, _ := strings.Cut(offer, ";")
+			if strings.TrimSpace(name) != "permessage-deflate" {
+				continue
+			}
+			if !strings.Contains(params, "server_max_window_bits") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Subprotocol returns the negotiated subprotocol, or an empty string.
+func (ws *WebSocketConn) Subprotocol() string {
+	return ws.subprotocol
+}
+
+// Compressed reports whether permessage-deflate has been negotiated.
+func (ws *WebSocketConn) Compressed() bool {
+	return ws.compress
+}
+
+// NetConn returns the underlying network connection.
+func (ws *WebSocketConn) NetConn() net.Conn {
+	return ws.conn
+}
+
+// RemoteAddr returns the network address of the client.
+func (ws *WebSocketConn) RemoteAddr() net.Addr {
+	return ws.conn.RemoteAddr()
+}
+
+// SetReadDeadline sets the deadline for reading from the connection.
+func (ws *WebSocketConn) SetReadDeadline(t time.Time) error {
+	return ws.conn.SetReadDeadline(t)
+}
+
+// SetWriteDeadline sets the deadline for writing to the connection.
+func (ws *WebSocketConn) SetWriteDeadline(t time.Time) error {
+	return ws.conn.SetWriteDeadline(t)
+}
+
+// SetPingHandler sets the function called with the payload of ping frames.
+// By default, pings are answered with a pong carrying the same payload.
+func (ws *WebSocketConn) SetPingHandler(h func(data []byte) error) {
+	ws.pingHandler = h
+}
+
+// SetPongHandler sets the function called with the payload of pong frames.
+// By default, pongs are ignored.
+func (ws *WebSocketConn) SetPongHandler(h func(data []byte) error) {
+	ws.pongHandler = h
+}
+
+// ReadMessage reads the next text or binary message, joining fragmented
+// messages and handling control frames in between.
+//
+// A *WebSocketCloseError is returned when the client closes the connection
+// or violates the protocol; the close handshake is answered and the network
+// connection closed before ReadMessage returns.
+func (ws *WebSocketConn) ReadMessage() (messageType int, data []byte, err error) {
+	compressed := false
+
+	for {
+		fin, rsv1, opcode, payload, err := ws.readFrame(ws.readLimit - int64(len(data)))
+		if err != nil {
+			return 0, nil, ws.fail(err)
+		}
+
+		switch opcode {
+		case WebSocketPing:
+			handler := ws.pingHandler
+			if handler == nil {
+				handler = func(data []byte) error { return ws.WriteMessage(WebSocketPong, data) }
+			}
+			if err := handler(payload); err != nil {
+				return 0, nil, err
+			}
+			continue
+
+		case WebSocketPong:
+			if ws.pongHandler != nil {
+				if err := ws.pongHandler(payload); err != nil {
+					return 0, nil, err
+				}
+			}
+			continue
+
+		case WebSocketClose:
+			return 0, nil, ws.closeReceived(payload)
+
+		case websocketContinuation:
+			if messageType == 0 {
+				return 0, nil, ws.fail(&WebSocketCloseError{Code: WebSocketCloseProtocolError, Reason: "unexpected continuation frame"})
+			}
+
+		default:
+			if messageType != 0 {
+				return 0, nil, ws.fail(&WebSocketCloseError{Code: WebSocketCloseProtocolError, Reason: "expected continuation frame"})
+			}
+			messageType, compressed = int(opcode), rsv1
+		}
+
+		data = append(data, payload...)
+		if !fin {
+			continue
+		}
+
+		if compressed {
+			if data, err = ws.inflate(data); err != nil {
+				return 0, nil, ws.fail(err)
+			}
+		}
+		if messageType == WebSocketText && !utf8.Valid(data) {
+			return 0, nil, ws.fail(&WebSocketCloseError{Code: WebSocketCloseInvalidPayload, Reason: "invalid UTF-8 in text message"})
+		}
+
+		return messageType, data, nil
+	}
+}
+
+// ReadJSON reads the next message and decodes it as JSON into v.
+func (ws *WebSocketConn) ReadJSON(v any) error {
+	_, data, err := ws.ReadMessage()
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// WriteMessage writes a message of the given type. Text and binary messages
+// are compressed if permessage-deflate has been negotiated. The payload of
+// control messages (close, ping and pong) must not exceed 125 bytes.
+func (ws *WebSocketConn) WriteMessage(messageType int, data []byte) error {
+	switch messageType {
+	case WebSocketText, WebSocketBinary:
+		if !ws.compress {
+			return ws.writeFrame(byte(messageType), data, false)
+		}
+		compressed, err := deflate(data)
+		if err != nil {
+			return err
+		}
+		return ws.writeFrame(byte(messageType), compressed, true)
+
+	case WebSocketClose, WebSocketPing, WebSocketPong:
+		if len(data) > 125 {
+			return errors.New("nexora: websocket control message exceeds 125 bytes")
+		}
+		return ws.writeFrame(byte(messageType), data, false)
+	}
+
+	return errors.New("nexora: unknown websocket message type " + strconv.Itoa(messageType))
+}
+
+// WriteJSON encodes v as JSON and writes it as a text message.
+func (ws *WebSocketConn) WriteJSON(v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return ws.WriteMessage(WebSocketText, data)
+}
+
+// Close sends a normal close frame and closes the network connection.
+func (ws *WebSocketConn) Close() error {
+	return ws.CloseWithReason(WebSocketCloseNormal, "")
+}
+
+// CloseWithReason sends a close frame with the given code and reason, and
+// closes the network connection.
+func (ws *WebSocketConn) CloseWithReason(code int, reason string) error {
+	ws.writeClose(code, reason)
+	return ws.closeConn()
+}
+
+// closeReceived answers a close frame from the client and closes the connection.
+func (ws *WebSocketConn) closeReceived(payload []byte) error {
+	closeErr := &WebSocketCloseError{Code: WebSocketCloseNoStatus}
+
+	switch {
+	case len(payload) == 1:
+		return ws.fail(&WebSocketCloseError{Code: WebSocketCloseProtocolError, Reason: "invalid close frame"})
+	case len(payload) >= 2:
+		closeErr.Code = int(binary.BigEndian.Uint16(payload))
+		closeErr.Reason = string(payload[2:])
+		if !validCloseCode(closeErr.Code) {
+			return ws.fail(&WebSocketCloseError{Code: WebSocketCloseProtocolError, Reason: "invalid close code"})
+		}
+		if !utf8.ValidString(closeErr.Reason) {
+			return ws.fail(&WebSocketCloseError{Code: WebSocketCloseInvalidPayload, Reason: "invalid UTF-8 in close reason"})
+		}
+	}
+
+	if closeErr.Code == WebSocketCloseNoStatus {
+		ws.writeFrame(WebSocketClose, nil, false)
+	} else {
+		ws.writeClose(closeErr.Code, "")
+	}
+	ws.closeConn()
+	return closeErr
+}
+
+// validCloseCode reports whether code may be sent in a close frame.
+func validCloseCode(code int) bool {
+	switch {
+	case code >= 1000 && code <= 1003, code >= 1007 && code <= 1011:
+		return true
+	case code >= 3000 && code <= 4999:
+		return true
+	}
+	return false
+}
+
+// fail closes the connection after a read error. Protocol violations are
+// reported to the client with a close frame.
+func (ws *WebSocketConn) fail(err error) error {
+	var closeErr *WebSocketCloseError
+	if errors.As(err, &closeErr) {
+		ws.writeClose(closeErr.Code, closeErr.Reason)
+	}
+	ws.closeConn()
+	return err
+}
+
+func (ws *WebSocketConn) writeClose(code int, reason string) error {
+	if len(reason) > 123 {
+		reason = reason[:123]
+	}
+	payload := make([]byte, 2, 2+len(reason))
+	binary.BigEndian.PutUint16(payload, uint16(code))
+	payload = append(payload, reason...)
+	return ws.writeFrame(WebSocketClose, payload, false)
+}
+
+func (ws *WebSocketConn) closeConn() error {
+	err := net.ErrClosed
+	ws.closeOnce.Do(func() {
+		err = ws.conn.Close()
+	})
+	return err
+}
+
+// readFrame reads a single frame sent by the client. For data frames, limit
+// is the number of bytes the message may still grow by.
+func (ws *WebSocketConn) readFrame(limit int64) (fin, rsv1 bool, opcode byte, payload []byte, err error) {
+	var header [8]byte
+	if _, err = io.ReadFull(ws.br, header[:2]); err != nil {
+		return
+	}
+
+	fin = header[0]&0x80 != 0
+	rsv1 = header[0]&0x40 != 0
+	opcode = header[0] & 0x0f
+	masked := header[1]&0x80 != 0
+	length := uint64(header[1] & 0x7f)
+	control := opcode >= WebSocketClose
+
+	protocolError := func(reason string) error {
+		return &WebSocketCloseError{Code: WebSocketCloseProtocolError, Reason: reason}
+	}
+
+	switch {
+	case header[0]&0x30 != 0:
+		err = protocolError("reserved bits set")
+	case opcode > WebSocketBinary && !control, opcode > WebSocketPong:
+		err = protocolError("unknown opcode " + strconv.Itoa(int(opcode)))
+	case !masked:
+		err = protocolError("client frames must be masked")
+	case control && (!fin || length > 125):
+		err = protocolError("invalid control frame")
+	case rsv1 && (!ws.compress || control || opcode == websocketContinuation):
+		err = protocolError("unexpected compression bit")
+	}
+	if err != nil {
+		return
+	}
+
+	switch length {
+	case 126:
+		if _, err = io.ReadFull(ws.br, header[:2]); err != nil {
+			return
+		}
+		length = uint64(binary.BigEndian.Uint16(header[:2]))
+	case 127:
+		if _, err = io.ReadFull(ws.br, header[:8]); err != nil {
+			return
+		}
+		length = binary.BigEndian.Uint64(header[:8])
+	}
+
+	if !control && length > uint64(max(limit, 0)) {
+		err = &WebSocketCloseError{Code: WebSocketCloseMessageTooBig, Reason: "message too big"}
+		return
+	}
+
+	var mask [4]byte
+	if _, err = io.ReadFull(ws.br, mask[:]); err != nil {
+		return
+	}
+
+	payload = make([]byte, length)
+	if _, err = io.ReadFull(ws.br, payload); err != nil {
+		return
+	}
+	for i := range payload {
+		payload[i] ^= mask[i&3]
+	}
+
+	return
+}
+
+// writeFrame writes a single, final frame.
+func (ws *WebSocketConn) writeFrame(opcode byte, payload []byte, rsv1 bool) error {
+	ws.writeMu.Lock()
+	defer ws.writeMu.Unlock()
+
+	if ws.closeSent {
+		return ErrWebSocketClosed
+	}
+	if opcode == WebSocketClose {
+		ws.closeSent = true
+	}
+
+	var header [10]byte
+	header[0] = 0x80 | opcode
+	if rsv1 {
+		header[0] |= 0x40
+	}
+
+	n := 2
+	switch length := len(payload); {
+	case length <= 125:
+		header[1] = byte(length)
+	case length <= 0xffff:
+		header[1] = 126
+		binary.BigEndian.PutUint16(header[2:], uint16(length))
+		n += 2
+	default:
+		header[1] = 127
+		binary.BigEndian.PutUint64(header[2:], uint64(length))
+		n += 8
+	}
+
+	ws.bw.Write(header[:n])
+	ws.bw.Write(payload)
+	return ws.bw.Flush()
+}
+
+// flateWriterPool pools compressors, which are expensive to allocate.
+var flateWriterPool sync.Pool
+
+// deflate compresses a message for permessage-deflate. A new compression
+// context is used for every message (no_context_takeover).
+func deflate(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+
+	fw, _ := flateWriterPool.Get().(*flate.Writer)
+	if fw == nil {
+		var err error
+		if fw, err = flate.NewWriter(&buf, flate.BestSpeed); err != nil {
+			return nil, err
+		}
+	} else {
+		fw.Reset(&buf)
+	}
+	defer flateWriterPool.Put(fw)
+
+	if _, err := fw.Write(data); err != nil {
+		return nil, err
+	}
+	if err := fw.Flush(); err != nil {
+		return nil, err
+	}
+
+	// Remove the sync flush marker, as required by RFC 7692 section 7.2.1.
+	return bytes.TrimSuffix(buf.Bytes(), []byte(deflateTail[:4])), nil
+}
+
+// inflate decompresses a message compressed with permessage-deflate.
+func (ws *WebSocketConn) inflate(data []byte) ([]byte, error) {
+	fr := flate.NewReader(io.MultiReader(bytes.NewReader(data), strings.NewReader(deflateTail)))
+	defer fr.Close()
+
+	out, err := io.ReadAll(io.LimitReader(fr, ws.readLimit+1))
+	switch {
+	case err != nil:
+		return nil, &WebSocketCloseError{Code: WebSocketCloseInvalidPayload, Reason: "invalid compressed message"}
+	case int64(len(out)) > ws.readLimit:
+		return nil, &WebSocketCloseError{Code: WebSocketCloseMessageTooBig, Reason: "message too big"}
+	}
+	return out, nil
+}
@@ -0,0 +1,377 @@
+package nexora
+
+import (
+	"bufio"
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// wsClient is a minimal WebSocket client used to test the server side.
+type wsClient struct {
+	t    *testing.T
+	conn net.Conn
+	br   *bufio.Reader
+	resp *http.Response
+}
+
+func dialWebSocket(t *testing.T, srv *httptest.Server, path string, header http.Header) *wsClient {
+	t.Helper()
+
+	conn, err := net.Dial("tcp", strings.TrimPrefix(srv.URL, "http://"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+	t.Cleanup(func() { conn.Close() })
+
+	req, _ := http.NewRequest(http.MethodGet, srv.URL+path, nil)
+	req.Header.Set(HeaderConnection, "Upgrade")
+	req.Header.Set(HeaderUpgrade, "websocket")
+	req.Header.Set(HeaderSecWebSocketVersion, "13")
+	req.Header.Set(HeaderSecWebSocketKey, "dGhlIHNhbXBsZSBub25jZQ==")
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	if err := req.Write(conn); err != nil {
+		t.Fatal(err)
+	}
+
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &wsClient{t: t, conn: conn, br: br, resp: resp}
+}
+
+// writeFrame writes a masked frame, as clients must.
+func (c *wsClient) writeFrame(fin, rsv1 bool, opcode byte, payload []byte) {
+	c.writeRawFrame(fin, rsv1, true, opcode, payload)
+}
+
+func (c *wsClient) writeRawFrame(fin, rsv1, masked bool, opcode byte, payload []byte) {
+	c.t.Helper()
+
+	var frame bytes.Buffer
+	b0 := opcode
+	if fin {
+		b0 |= 0x80
+	}
+	if rsv1 {
+		b0 |= 0x40
+	}
+	frame.WriteByte(b0)
+
+	var maskBit byte
+	if masked {
+		maskBit = 0x80
+	}
+	switch {
+	case len(payload) <= 125:
+		frame.WriteByte(maskBit | byte(len(payload)))
+	case len(payload) <= 0xffff:
+		frame.WriteByte(maskBit | 126)
+		binary.Write(&frame, binary.BigEndian, uint16(len(payload)))
+	default:
+		frame.WriteByte(maskBit | 127)
+		binary.Write(&frame, binary.BigEndian, uint64(len(payload)))
+	}
+
+	if masked {
+		mask := []byte{1, 2, 3, 4}
+		frame.Write(mask)
+		for i, b := range payload {
+			frame.WriteByte(b ^ mask[i%4])
+		}
+	} else {
+		frame.Write(payload)
+	}
+
+	if _, err := c.conn.Write(frame.Bytes()); err != nil {
+		c.t.Fatal(err)
+	}
+}
+
+// readFrame reads an unmasked server frame.
+func (c *wsClient) readFrame() (opcode byte, rsv1 bool, payload []byte) {
+	c.t.Helper()
+
+	var header [2]byte
+	if _, err := io.ReadFull(c.br, header[:]); err != nil {
+		c.t.Fatal(err)
+	}
+	if header[1]&0x80 != 0 {
+		c.t.Fatal("server frame is masked")
+	}
+
+	length := uint64(header[1] & 0x7f)
+	switch length {
+	case 126:
+		var n uint16
+		binary.Read(c.br, binary.BigEndian, &n)
+		length = uint64(n)
+	case 127:
+		binary.Read(c.br, binary.BigEndian, &length)
+	}
+
+	payload = make([]byte, length)
+	if _, err := io.ReadFull(c.br, payload); err != nil {
+		c.t.Fatal(err)
+	}
+	return header[0] & 0x0f, header[0]&0x40 != 0, payload
+}
+
+func (c *wsClient) expectClose(code int) {
+	c.t.Helper()
+	opcode, _, payload := c.readFrame()
+	if opcode != WebSocketClose || len(payload) < 2 {
+		c.t.Fatalf("expected close frame, got opcode %d payload %q", opcode, payload)
+	}
+	if got := int(binary.BigEndian.Uint16(payload)); got != code {
+		c.t.Fatalf("close code = %d, want %d (%s)", got, code, payload[2:])
+	}
+}
+
+func closePayload(code int, reason string) []byte {
+	payload := binary.BigEndian.AppendUint16(nil, uint16(code))
+	return append(payload, reason...)
+}
+
+// echoServer starts a server echoing messages on /ws/{room} behind an auth
+// middleware. The error returned by ReadMessage is sent to errs.
+func echoServer(t *testing.T, cfg WebSocketConfig, errs chan<- error) *httptest.Server {
+	app := New()
+	api := app.Group("", func(c *Context) error {
+		if c.Query("token") != "secret" {
+			return ErrUnauthorized
+		}
+		c.SetHeader("X-Room", c.Param("room"))
+		return c.Next()
+	})
+	api.Get("/ws/{room}", func(c *Context) error {
+		ws, err := c.WebSocket(cfg)
+		if err != nil {
+			return err
+		}
+		defer ws.Close()
+
+		for {
+			typ, msg, err := ws.ReadMessage()
+			if err != nil {
+				if errs != nil {
+					errs <- err
+				}
+				return nil
+			}
+			if err := ws.WriteMessage(typ, msg); err != nil {
+				return nil
+			}
+		}
+	})
+
+	srv := httptest.NewServer(app)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestWebSocket_Handshake(t *testing.T) {
+	srv := echoServer(t, WebSocketConfig{Subprotocols: []string{"chat.v2", "chat.v1"}}, nil)
+
+	client := dialWebSocket(t, srv, "/ws/lobby?token=secret", http.Header{
+		HeaderSecWebSocketProtocol: {"chat.v1, chat.v2"},
+	})
+
+	if client.resp.StatusCode != StatusSwitchingProtocols {
+		t.Fatalf("status = %d, want 101", client.resp.StatusCode)
+	}
+	// Example key and accept value from RFC 6455 section 1.3
+	if got := client.resp.Header.Get(HeaderSecWebSocketAccept); got != "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=" {
+		t.Errorf("Sec-WebSocket-Accept = %q", got)
+	}
+	if got := client.resp.Header.Get(HeaderSecWebSocketProtocol); got != "chat.v1" {
+		t.Errorf("Sec-WebSocket-Protocol = %q, want %q", got, "chat.v1")
+	}
+	if got := client.resp.Header.Get("X-Room"); got != "lobby" {
+		t.Errorf("X-Room = %q, want header set by middleware", got)
+	}
+	if got := client.resp.Header.Get(HeaderSecWebSocketExtensions); got != "" {
+		t.Errorf("Sec-WebSocket-Extensions = %q without compression enabled", got)
+	}
+}
+
+func TestWebSocket_HandshakeErrors(t *testing.T) {
+	srv := echoServer(t, WebSocketConfig{}, nil)
+
+	tests := []struct {
+		name     string
+		path     string
+		header   http.Header
+		wantCode int
+	}{
+		{"middleware rejects", "/ws/lobby", nil, StatusUnauthorized},
+		{"wrong version", "/ws/lobby?token=secret", http.Header{HeaderSecWebSocketVersion: {"8"}}, StatusUpgradeRequired},
+		{"not an upgrade", "/ws/lobby?token=secret", http.Header{HeaderUpgrade: {"h2c"}}, StatusUpgradeRequired},
+		{"bad key", "/ws/lobby?token=secret", http.Header{HeaderSecWebSocketKey: {"short"}}, StatusBadRequest},
+		{"cross origin", "/ws/lobby?token=secret", http.Header{HeaderOrigin: {"https://evil.example"}}, StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := dialWebSocket(t, srv, tt.path, tt.header)
+			if client.resp.StatusCode != tt.wantCode {
+				t.Errorf("status = %d, want %d", client.resp.StatusCode, tt.wantCode)
+			}
+		})
+	}
+
+	t.Run("same origin", func(t *testing.T) {
+		client := dialWebSocket(t, srv, "/ws/lobby?token=secret", http.Header{HeaderOrigin: {srv.URL}})
+		if client.resp.StatusCode != StatusSwitchingProtocols {
+			t.Errorf("status = %d, want 101", client.resp.StatusCode)
+		}
+	})
+}
+
+func TestWebSocket_Messages(t *testing.T) {
+	errs := make(chan error, 1)
+	srv := echoServer(t, WebSocketConfig{}, errs)
+	client := dialWebSocket(t, srv, "/ws/lobby?token=secret", nil)
+
+	// Text and binary messages are echoed
+	client.writeFrame(true, false, WebSocketText, []byte("hello"))
+	if op, _, payload := client.readFrame(); op != WebSocketText || string(payload) != "hello" {
+		t.Errorf("echo = %d %q", op, payload)
+	}
+
+	large := bytes.Repeat([]byte{0xfe}, 70000)
+	client.writeFrame(true, false, WebSocketBinary, large)
+	if op, _, payload := client.readFrame(); op != WebSocketBinary || !bytes.Equal(payload, large) {
+		t.Errorf("binary echo = %d, %d bytes", op, len(payload))
+	}
+
+	// Fragmented message with a ping in between
+	client.writeFrame(false, false, WebSocketText, []byte("frag"))
+	client.writeFrame(true, false, WebSocketPing, []byte("are you there"))
+	client.writeFrame(true, false, websocketContinuation, []byte("mented"))
+	if op, _, payload := client.readFrame(); op != WebSocketPong || string(payload) != "are you there" {
+		t.Errorf("pong = %d %q", op, payload)
+	}
+	if op, _, payload := client.readFrame(); op != WebSocketText || string(payload) != "fragmented" {
+		t.Errorf("fragmented echo = %d %q", op, payload)
+	}
+
+	// Close handshake
+	client.writeFrame(true, false, WebSocketClose, closePayload(WebSocketCloseGoingAway, "bye"))
+	client.expectClose(WebSocketCloseGoingAway)
+
+	var closeErr *WebSocketCloseError
+	if err := <-errs; !errors.As(err, &closeErr) || closeErr.Code != WebSocketCloseGoingAway || closeErr.Reason != "bye" {
+		t.Errorf("ReadMessage() error = %v", err)
+	}
+}
+
+func TestWebSocket_ProtocolErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		send     func(c *wsClient)
+		wantCode int
+	}{
+		{"unmasked frame", func(c *wsClient) {
+			c.writeRawFrame(true, false, false, WebSocketText, []byte("hi"))
+		}, WebSocketCloseProtocolError},
+		{"unknown opcode", func(c *wsClient) {
+			c.writeFrame(true, false, 3, nil)
+		}, WebSocketCloseProtocolError},
+		{"unexpected continuation", func(c *wsClient) {
+			c.writeFrame(true, false, websocketContinuation, []byte("x"))
+		}, WebSocketCloseProtocolError},
+		{"fragmented control frame", func(c *wsClient) {
+			c.writeFrame(false, false, WebSocketPing, nil)
+		}, WebSocketCloseProtocolError},
+		{"compression not negotiated", func(c *wsClient) {
+			c.writeFrame(true, true, WebSocketText, []byte("x"))
+		}, WebSocketCloseProtocolError},
+		{"invalid utf-8", func(c *wsClient) {
+			c.writeFrame(true, false, WebSocketText, []byte{0xff, 0xfe})
+		}, WebSocketCloseInvalidPayload},
+		{"read limit", func(c *wsClient) {
+			c.writeFrame(true, false, WebSocketBinary, make([]byte, 65))
+		}, WebSocketCloseMessageTooBig},
+		{"read limit across fragments", func(c *wsClient) {
+			c.writeFrame(false, false, WebSocketBinary, make([]byte, 40))
+			c.writeFrame(true, false, websocketContinuation, make([]byte, 40))
+		}, WebSocketCloseMessageTooBig},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := make(chan error, 1)
+			srv := echoServer(t, WebSocketConfig{ReadLimit: 64}, errs)
+			client := dialWebSocket(t, srv, "/ws/lobby?token=secret", nil)
+
+			tt.send(client)
+			client.expectClose(tt.wantCode)
+
+			var closeErr *WebSocketCloseError
+			if err := <-errs; !errors.As(err, &closeErr) || closeErr.Code != tt.wantCode {
+				t.Errorf("ReadMessage() error = %v, want close code %d", err, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestWebSocket_Compression(t *testing.T) {
+	srv := echoServer(t, WebSocketConfig{EnableCompression: true}, nil)
+	client := dialWebSocket(t, srv, "/ws/lobby?token=secret", http.Header{
+		HeaderSecWebSocketExtensions: {"permessage-deflate; client_max_window_bits"},
+	})
+
+	want := "permessage-deflate; server_no_context_takeover; client_no_context_takeover"
+	if got := client.resp.Header.Get(HeaderSecWebSocketExtensions); got != want {
+		t.Fatalf("Sec-WebSocket-Extensions = %q, want %q", got, want)
+	}
+
+	message := strings.Repeat("compress me ", 100)
+	compressed, err := deflate([]byte(message))
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.writeFrame(true, true, WebSocketText, compressed)
+
+	op, rsv1, payload := client.readFrame()
+	if op != WebSocketText || !rsv1 {
+		t.Fatalf("echo opcode = %d, rsv1 = %v, want compressed text", op, rsv1)
+	}
+	if len(payload) >= len(message) {
+		t.Errorf("compressed echo is %d bytes, message is %d", len(payload), len(message))
+	}
+
+	fr := flate.NewReader(io.MultiReader(bytes.NewReader(payload), strings.NewReader(deflateTail)))
+	decompressed, err := io.ReadAll(fr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(decompressed) != message {
+		t.Errorf("decompressed echo = %q", decompressed)
+	}
+}
+
+func TestWebSocket_CompressionDeclined(t *testing.T) {
+	srv := echoServer(t, WebSocketConfig{EnableCompression: true}, nil)
+	client := dialWebSocket(t, srv, "/ws/lobby?token=secret", http.Header{
+		HeaderSecWebSocketExtensions: {"permessage-deflate; server_max_window_bits=10"},
+	})
+
+	if got := client.resp.Header.Get(HeaderSecWebSocketExtensions); got != "" {
+		t.Errorf("Sec-WebSocket-Extensions = %q, want offer to be declined", got)
+	}
+}